@@ -0,0 +1,55 @@
+// Package admin serves operator endpoints of the standalone server. They
+// are not deployed on Vercel, where every function has its own token pool.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/config"
+	"api_git_leet_duo/api/git/githubclient"
+)
+
+// authorized reports whether r carries "Authorization: Bearer <secret>".
+func authorized(r *http.Request, secret string) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
+// AdminTokens reports the health of the GitHub token pool. Token values are
+// masked. It answers 404 while ADMIN_SECRET is unset and 401 without it.
+func AdminTokens(w http.ResponseWriter, r *http.Request) {
+	secret := config.FromContext(r.Context()).AdminSecret
+	if secret == "" {
+		apierror.Write(w, apierror.New(http.StatusNotFound, apierror.NotFound, "not found"))
+		return
+	}
+	if !authorized(r, secret) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		apierror.Write(w, apierror.New(http.StatusUnauthorized, apierror.Unauthorized, "missing or invalid admin secret"))
+		return
+	}
+
+	var health []githubclient.TokenHealth
+	if pool, ok := githubclient.FromContext(r.Context()).Tokens.(*githubclient.TokenPool); ok {
+		health = pool.Health()
+	}
+
+	available := 0
+	for _, token := range health {
+		if !token.Revoked && !token.Exhausted {
+			available++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tokens":    health,
+		"total":     len(health),
+		"available": available,
+	})
+}
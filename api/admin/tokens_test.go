@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api_git_leet_duo/api/config"
+)
+
+func TestAdminTokensRequiresSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		want   int
+	}{
+		{name: "disabled without a secret", secret: "", header: "Bearer anything", want: http.StatusNotFound},
+		{name: "missing header", secret: "s3cret", want: http.StatusUnauthorized},
+		{name: "wrong secret", secret: "s3cret", header: "Bearer guess", want: http.StatusUnauthorized},
+		{name: "right secret", secret: "s3cret", header: "Bearer s3cret", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/admin/tokens", nil)
+			r = r.WithContext(config.WithConfig(r.Context(), config.Config{AdminSecret: tt.secret}))
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			AdminTokens(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package apierror writes the JSON error body shared by every endpoint:
+//
+//	{"error": {"code": "USER_NOT_FOUND", "message": "...", "provider": "github"}}
+//
+// Codes are stable and meant for clients; messages are for humans.
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/upstream"
+)
+
+// Code is a stable machine-readable error code.
+type Code string
+
+const (
+	BadParam            Code = "BAD_PARAM"
+	Unauthorized        Code = "UNAUTHORIZED"
+	UserNotFound        Code = "USER_NOT_FOUND"
+	NotFound            Code = "NOT_FOUND"
+	UpstreamRateLimited Code = "UPSTREAM_RATE_LIMITED"
+	UpstreamTimeout     Code = "UPSTREAM_TIMEOUT"
+	UpstreamError       Code = "UPSTREAM_ERROR"
+	Internal            Code = "INTERNAL_ERROR"
+)
+
+// Error is the body of an error response. RetryAfter is in seconds.
+type Error struct {
+	Status     int    `json:"-"`
+	Code       Code   `json:"code"`
+	Message    string `json:"message"`
+	Provider   string `json:"provider,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return string(e.Code) + ": " + e.Message
+}
+
+// New returns an Error with the given status, code and message.
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// MissingParam reports a required query parameter that was not sent.
+func MissingParam(name string) *Error {
+	return New(http.StatusBadRequest, BadParam, fmt.Sprintf("missing '%s' parameter", name))
+}
+
+// BadRequest reports invalid input whose error already names the parameter,
+// such as a username rejected by package validate.
+func BadRequest(err error) *Error {
+	return New(http.StatusBadRequest, BadParam, err.Error())
+}
+
+// InvalidParam reports a query parameter with an unusable value.
+func InvalidParam(name string, err error) *Error {
+	return New(http.StatusBadRequest, BadParam, fmt.Sprintf("invalid '%s' parameter: %v", name, err))
+}
+
+// FromUpstream classifies an error returned while calling provider
+// ("github", "leetcode", "duolingo").
+func FromUpstream(provider string, err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	e := &Error{Provider: provider, Message: err.Error()}
+	var rlErr *githubclient.RateLimitError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, upstream.ErrUserNotFound) || githubclient.IsNotFound(err):
+		e.Status, e.Code = http.StatusNotFound, UserNotFound
+	case errors.As(err, &rlErr):
+		// The token pool answers 503 when every token is exhausted; GitHub
+		// itself answers 429 or 403, both reported as 429.
+		e.Status, e.Code = http.StatusTooManyRequests, UpstreamRateLimited
+		if rlErr.StatusCode == http.StatusServiceUnavailable {
+			e.Status = http.StatusServiceUnavailable
+		}
+		e.RetryAfter = retryAfter(rlErr)
+	case errors.Is(err, upstream.ErrRateLimited) || githubclient.IsRateLimited(err):
+		e.Status, e.Code = http.StatusTooManyRequests, UpstreamRateLimited
+	case errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout():
+		e.Status, e.Code = http.StatusGatewayTimeout, UpstreamTimeout
+		e.Message = provider + " did not answer in time"
+	case errors.Is(err, githubclient.ErrNoToken):
+		e.Status, e.Code = http.StatusServiceUnavailable, Internal
+	default:
+		e.Status, e.Code = http.StatusBadGateway, UpstreamError
+	}
+	return e
+}
+
+func retryAfter(rlErr *githubclient.RateLimitError) int {
+	wait := rlErr.RetryAfter
+	if wait <= 0 && !rlErr.ResetAt.IsZero() {
+		wait = time.Until(rlErr.ResetAt)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return int(math.Ceil(wait.Seconds()))
+}
+
+// Write sends err as a JSON error response, with a Retry-After header when
+// the client should back off.
+func Write(w http.ResponseWriter, err *Error) {
+	h := w.Header()
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("Cache-Control", "no-store")
+	h.Del("ETag")
+	h.Del("Last-Modified")
+	if err.RetryAfter > 0 {
+		h.Set("Retry-After", strconv.Itoa(err.RetryAfter))
+	}
+	w.WriteHeader(err.Status)
+	json.NewEncoder(w).Encode(map[string]*Error{"error": err})
+}
@@ -0,0 +1,110 @@
+// Package cache stores upstream responses so repeated requests for the same
+// user don't hit GitHub, LeetCode or Duolingo again.
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"api_git_leet_duo/api/config"
+)
+
+const (
+	// StaleWindow is how long an expired entry may still be served while it
+	// is refreshed in the background.
+	StaleWindow = 24 * time.Hour
+	// PastYearTTL is used for contribution calendars of past years, which
+	// rarely change.
+	PastYearTTL = 7 * 24 * time.Hour
+	// memoryCapacity bounds the number of entries of the default in-memory cache.
+	memoryCapacity = 512
+	// fileCapacity bounds the number of entries kept under CACHE_DIR.
+	fileCapacity = 10000
+)
+
+// Entry is a cached value with its freshness information.
+type Entry struct {
+	Value      []byte    `json:"value"`
+	StoredAt   time.Time `json:"stored_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	StaleUntil time.Time `json:"stale_until"`
+}
+
+// Fresh reports whether the entry can be served without refreshing.
+func (e Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Usable reports whether the entry can still be served, fresh or stale.
+func (e Entry) Usable(now time.Time) bool {
+	return now.Before(e.StaleUntil)
+}
+
+// Cache is implemented by every cache backend.
+type Cache interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry)
+	Delete(key string)
+}
+
+// Key builds a cache key from the provider ("github", "leetcode",
+// "duolingo"), the endpoint, the user and any parameters that change the
+// response. Users are case-insensitive on every provider.
+func Key(provider, endpoint, user string, params url.Values) string {
+	key := provider + ":" + endpoint + ":" + strings.ToLower(user)
+	if encoded := params.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
+
+// TTL returns the CACHE_TTL of the configuration carried by ctx.
+func TTL(ctx context.Context) time.Duration {
+	return config.FromContext(ctx).CacheTTL
+}
+
+// New returns the cache described by cfg: file-backed under CacheDir when it
+// is set, in-memory otherwise or when the directory cannot be created.
+func New(cfg config.Config) Cache {
+	if cfg.CacheDir != "" {
+		fc, err := NewFile(cfg.CacheDir, fileCapacity)
+		if err == nil {
+			return fc
+		}
+		slog.Warn("cache: falling back to memory", "dir", cfg.CacheDir, "error", err)
+	}
+	return NewMemory(memoryCapacity)
+}
+
+var (
+	defaultOnce  sync.Once
+	defaultCache Cache
+)
+
+// Default returns the cache built lazily from config.Get. Only serverless
+// handlers, which never run the server's setup, end up using it.
+func Default() Cache {
+	defaultOnce.Do(func() {
+		defaultCache = New(config.Get())
+	})
+	return defaultCache
+}
+
+type contextKey struct{}
+
+// WithCache returns a copy of ctx carrying c.
+func WithCache(ctx context.Context, c Cache) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the cache carried by ctx, falling back to Default.
+func FromContext(ctx context.Context) Cache {
+	if c, ok := ctx.Value(contextKey{}).(Cache); ok {
+		return c
+	}
+	return Default()
+}
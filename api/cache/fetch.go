@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Status tells how a value was served.
+type Status string
+
+const (
+	Hit   Status = "HIT"
+	Stale Status = "STALE"
+	Miss  Status = "MISS"
+)
+
+// refreshTimeout bounds a background refresh, which outlives the request
+// that triggered it.
+const refreshTimeout = 30 * time.Second
+
+// Result describes the entry a value was served from.
+type Result struct {
+	Status    Status
+	StoredAt  time.Time
+	ExpiresAt time.Time
+}
+
+// refreshing holds the keys being refreshed in the background, so a burst
+// of requests on a stale entry triggers a single upstream call.
+var refreshing sync.Map
+
+// Fetch returns the value cached under key, calling fetch on a miss. A
+// stale entry is served immediately while fetch refreshes it in the
+// background with a context that keeps ctx's values but not its deadline.
+// Errors are never cached.
+func Fetch[T any](ctx context.Context, c Cache, key string, ttl time.Duration, fetch func(context.Context) (T, error)) (T, Result, error) {
+	now := time.Now()
+	if entry, ok := c.Get(key); ok && entry.Usable(now) {
+		var value T
+		if err := json.Unmarshal(entry.Value, &value); err == nil {
+			result := Result{Status: Hit, StoredAt: entry.StoredAt, ExpiresAt: entry.ExpiresAt}
+			if !entry.Fresh(now) {
+				result.Status = Stale
+				refresh(ctx, c, key, ttl, fetch)
+			}
+			return value, result, nil
+		}
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		var zero T
+		return zero, Result{}, err
+	}
+	entry := store(c, key, ttl, value)
+	return value, Result{Status: Miss, StoredAt: entry.StoredAt, ExpiresAt: entry.ExpiresAt}, nil
+}
+
+func refresh[T any](parent context.Context, c Cache, key string, ttl time.Duration, fetch func(context.Context) (T, error)) {
+	if _, running := refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer refreshing.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), refreshTimeout)
+		defer cancel()
+
+		if value, err := fetch(ctx); err == nil {
+			store(c, key, ttl, value)
+		}
+	}()
+}
+
+func store[T any](c Cache, key string, ttl time.Duration, value T) Entry {
+	now := time.Now()
+	entry := Entry{
+		StoredAt:   now,
+		ExpiresAt:  now.Add(ttl),
+		StaleUntil: now.Add(ttl + StaleWindow),
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return entry
+	}
+	entry.Value = data
+	c.Set(key, entry)
+	return entry
+}
+
+// Computed describes a response derived from cached data, valid for ttl.
+func Computed(ttl time.Duration) Result {
+	now := time.Now()
+	return Result{StoredAt: now, ExpiresAt: now.Add(ttl)}
+}
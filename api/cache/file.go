@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often Set triggers a sweep of the cache directory.
+const sweepInterval = 10 * time.Minute
+
+// File stores one JSON file per entry in a directory, so cached data
+// survives restarts. Entries past StaleUntil are deleted when read and by a
+// periodic sweep, which also trims the directory to capacity entries.
+type File struct {
+	dir      string
+	capacity int
+
+	mu        sync.Mutex
+	lastSweep time.Time
+	sweeping  bool
+}
+
+// NewFile returns a file-backed cache rooted at dir, creating it if needed.
+// A capacity of zero or less disables the size cap.
+func NewFile(dir string, capacity int) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir, capacity: capacity, lastSweep: time.Now()}, nil
+}
+
+func (f *File) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (f *File) Get(key string) (Entry, bool) {
+	path := f.path(key)
+	entry, ok := readEntry(path)
+	if !ok {
+		return Entry{}, false
+	}
+	if !entry.Usable(time.Now()) {
+		os.Remove(path)
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+func (f *File) Set(key string, entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so readers never see a partial entry.
+	tmp, err := os.CreateTemp(f.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+
+	f.mu.Lock()
+	due := !f.sweeping && time.Since(f.lastSweep) >= sweepInterval
+	if due {
+		f.sweeping = true
+	}
+	f.mu.Unlock()
+	if due {
+		go f.Sweep()
+	}
+}
+
+func (f *File) Delete(key string) {
+	os.Remove(f.path(key))
+}
+
+// Sweep deletes entries past StaleUntil, unreadable entries and abandoned
+// temporary files, then removes the least recently written entries until at
+// most capacity remain.
+func (f *File) Sweep() {
+	defer func() {
+		f.mu.Lock()
+		f.sweeping = false
+		f.lastSweep = time.Now()
+		f.mu.Unlock()
+	}()
+
+	files, err := os.ReadDir(f.dir)
+	if err != nil {
+		return
+	}
+
+	type kept struct {
+		path    string
+		modTime time.Time
+	}
+	var entries []kept
+	now := time.Now()
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(f.dir, file.Name())
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(file.Name(), ".tmp"):
+			if now.Sub(info.ModTime()) > time.Minute {
+				os.Remove(path)
+			}
+		case strings.HasSuffix(file.Name(), ".json"):
+			if entry, ok := readEntry(path); !ok || !entry.Usable(now) {
+				os.Remove(path)
+				continue
+			}
+			entries = append(entries, kept{path: path, modTime: info.ModTime()})
+		}
+	}
+
+	if f.capacity <= 0 || len(entries) <= f.capacity {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, entry := range entries[:len(entries)-f.capacity] {
+		os.Remove(entry.path)
+	}
+}
+
+func readEntry(path string) (Entry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, false
+	}
+	return entry, true
+}
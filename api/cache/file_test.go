@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func entryAt(now time.Time, staleFor time.Duration) Entry {
+	return Entry{Value: []byte(`{}`), StoredAt: now, ExpiresAt: now, StaleUntil: now.Add(staleFor)}
+}
+
+func countEntries(t *testing.T, dir string) int {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(files)
+}
+
+func TestFileGetDeletesExpiredEntry(t *testing.T) {
+	dir := t.TempDir()
+	f, err := NewFile(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Set("gone", entryAt(time.Now(), -time.Second))
+	f.Set("stale", entryAt(time.Now(), time.Hour))
+
+	if _, ok := f.Get("gone"); ok {
+		t.Error("Get returned an entry past StaleUntil")
+	}
+	if _, err := os.Stat(f.path("gone")); !os.IsNotExist(err) {
+		t.Errorf("expired entry still on disk: %v", err)
+	}
+	if _, ok := f.Get("stale"); !ok {
+		t.Error("Get dropped an entry that is still usable")
+	}
+}
+
+func TestFileSweep(t *testing.T) {
+	dir := t.TempDir()
+	f, err := NewFile(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Set("expired", entryAt(time.Now(), -time.Second))
+	for i, key := range []string{"oldest", "middle", "newest"} {
+		f.Set(key, entryAt(time.Now(), time.Hour))
+		modTime := time.Now().Add(time.Duration(i-3) * time.Minute)
+		os.Chtimes(f.path(key), modTime, modTime)
+	}
+	abandoned := filepath.Join(dir, "entry-abandoned.tmp")
+	os.WriteFile(abandoned, []byte("partial"), 0o644)
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(abandoned, old, old)
+
+	f.Sweep()
+
+	if got := countEntries(t, dir); got != 2 {
+		t.Errorf("%d entries after sweep, want 2", got)
+	}
+	if _, ok := f.Get("oldest"); ok {
+		t.Error("sweep kept the least recently written entry over capacity")
+	}
+	for _, key := range []string{"middle", "newest"} {
+		if _, ok := f.Get(key); !ok {
+			t.Errorf("sweep removed %q", key)
+		}
+	}
+	if _, err := os.Stat(abandoned); !os.IsNotExist(err) {
+		t.Error("sweep kept an abandoned temporary file")
+	}
+}
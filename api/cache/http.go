@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+)
+
+// WriteJSON encodes v and writes it with caching headers, answering 304
+// when the client already has the same body.
+func WriteJSON(w http.ResponseWriter, r *http.Request, v interface{}, result Result) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		apierror.Write(w, apierror.New(http.StatusInternalServerError, apierror.Internal, "encoding response: "+err.Error()))
+		return
+	}
+	Write(w, r, "application/json", append(body, '\n'), result)
+}
+
+// Write sends body with Cache-Control, ETag and X-Cache headers derived
+// from result, answering 304 when If-None-Match matches.
+func Write(w http.ResponseWriter, r *http.Request, contentType string, body []byte, result Result) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	maxAge := int(time.Until(result.ExpiresAt).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	h := w.Header()
+	h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d, s-maxage=%d, stale-while-revalidate=%d", maxAge, maxAge, int(StaleWindow.Seconds())))
+	h.Set("ETag", etag)
+	if result.Status != "" {
+		h.Set("X-Cache", string(result.Status))
+	}
+	if !result.StoredAt.IsZero() {
+		h.Set("Last-Modified", result.StoredAt.UTC().Format(http.TimeFormat))
+	}
+
+	if matchesETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
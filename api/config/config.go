@@ -0,0 +1,214 @@
+// Package config loads the server configuration from the environment and
+// an optional .env file.
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config is the typed server configuration.
+type Config struct {
+	Port         string
+	Environment  string
+	LogLevel     string
+	GitHubTokens []string
+	CacheTTL     time.Duration
+	CacheDir     string
+	// AdminSecret guards /api/admin/*; the admin endpoints are disabled
+	// while it is empty.
+	AdminSecret string
+}
+
+// Defaults used when a variable is not set.
+const (
+	DefaultPort        = "8080"
+	DefaultEnvironment = "development"
+	DefaultLogLevel    = "info"
+	DefaultCacheTTL    = time.Hour
+)
+
+var (
+	environments = []string{"development", "production", "test"}
+	logLevels    = []string{"debug", "info", "warn", "error"}
+)
+
+// IsProduction reports whether ENVIRONMENT is production.
+func (c Config) IsProduction() bool {
+	return c.Environment == "production"
+}
+
+// Level is the slog level for LogLevel.
+func (c Config) Level() slog.Level {
+	switch c.LogLevel {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Addr is the listen address for Port.
+func (c Config) Addr() string {
+	return ":" + c.Port
+}
+
+// Load reads the configuration from the process environment, falling back
+// to values from a .env file in the working directory. The returned Config
+// always holds usable defaults, even when an error reports invalid values.
+func Load() (Config, error) {
+	dotenv, err := ReadDotEnv(".env")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		cfg, cfgErr := FromLookup(os.LookupEnv)
+		return cfg, errors.Join(fmt.Errorf("reading .env: %w", err), cfgErr)
+	}
+
+	return FromLookup(func(key string) (string, bool) {
+		if value, ok := os.LookupEnv(key); ok {
+			return value, true
+		}
+		value, ok := dotenv[key]
+		return value, ok
+	})
+}
+
+// FromLookup builds and validates a Config from lookup.
+func FromLookup(lookup func(string) (string, bool)) (Config, error) {
+	get := func(key, fallback string) string {
+		if value, ok := lookup(key); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+		return fallback
+	}
+
+	cfg := Config{
+		Port:         get("PORT", DefaultPort),
+		Environment:  strings.ToLower(get("ENVIRONMENT", DefaultEnvironment)),
+		LogLevel:     strings.ToLower(get("LOG_LEVEL", DefaultLogLevel)),
+		GitHubTokens: readTokens(lookup),
+		CacheTTL:     DefaultCacheTTL,
+		CacheDir:     get("CACHE_DIR", ""),
+		AdminSecret:  get("ADMIN_SECRET", ""),
+	}
+
+	var problems []string
+
+	if port, err := strconv.Atoi(cfg.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT must be a number between 1 and 65535, got %q", cfg.Port))
+		cfg.Port = DefaultPort
+	}
+	if !contains(environments, cfg.Environment) {
+		problems = append(problems, fmt.Sprintf("ENVIRONMENT must be one of %s, got %q", strings.Join(environments, ", "), cfg.Environment))
+		cfg.Environment = DefaultEnvironment
+	}
+	if !contains(logLevels, cfg.LogLevel) {
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL must be one of %s, got %q", strings.Join(logLevels, ", "), cfg.LogLevel))
+		cfg.LogLevel = DefaultLogLevel
+	}
+	if ttl := get("CACHE_TTL", ""); ttl != "" {
+		if seconds, err := strconv.Atoi(ttl); err != nil || seconds < 0 {
+			problems = append(problems, fmt.Sprintf("CACHE_TTL must be a non-negative number of seconds, got %q", ttl))
+		} else {
+			cfg.CacheTTL = time.Duration(seconds) * time.Second
+		}
+	}
+	if cfg.IsProduction() && len(cfg.GitHubTokens) == 0 {
+		problems = append(problems, "GITHUB_TOKEN (or TOKEN) is required in production")
+	}
+
+	if len(problems) > 0 {
+		return cfg, errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return cfg, nil
+}
+
+// readTokens collects GITHUB_TOKEN, GITHUB_TOKEN2... and TOKEN, TOKEN2...,
+// each series stopping at its first gap. Duplicates are dropped.
+func readTokens(lookup func(string) (string, bool)) []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, prefix := range []string{"GITHUB_TOKEN", "TOKEN"} {
+		for i := 1; ; i++ {
+			key := prefix
+			if i > 1 {
+				key = fmt.Sprintf("%s%d", prefix, i)
+			}
+			token, ok := lookup(key)
+			if !ok {
+				break
+			}
+			token = strings.TrimSpace(token)
+			if token != "" && !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	mu      sync.Mutex
+	current *Config
+)
+
+// Set installs cfg as the configuration returned by Get. main calls it
+// after validating the configuration at startup.
+func Set(cfg Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = &cfg
+}
+
+// Get returns the configuration installed by Set. Serverless handlers,
+// which never run main, load it lazily on first use.
+func Get() Config {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current == nil {
+		cfg, err := Load()
+		if err != nil {
+			slog.Warn("config", "error", err)
+		}
+		current = &cfg
+	}
+	return *current
+}
+
+type contextKey struct{}
+
+// WithConfig returns a copy of ctx carrying cfg. The standalone server
+// installs its configuration on every request this way.
+func WithConfig(ctx context.Context, cfg Config) context.Context {
+	return context.WithValue(ctx, contextKey{}, cfg)
+}
+
+// FromContext returns the configuration carried by ctx, falling back to Get
+// for serverless handlers.
+func FromContext(ctx context.Context) Config {
+	if cfg, ok := ctx.Value(contextKey{}).(Config); ok {
+		return cfg
+	}
+	return Get()
+}
@@ -1,8 +1,11 @@
 package duo
 
 import (
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
 	"api_git_leet_duo/api/duo/tools"
-	"encoding/json"
+	"api_git_leet_duo/api/validate"
+	"context"
 	"net/http"
 
 )
@@ -30,18 +33,24 @@ type APIResponse struct {
 func DuoUser(w http.ResponseWriter, r *http.Request) {
 	user := r.URL.Query().Get("user")
 	if user == "" {
-		http.Error(w, "Parâmetro 'user' é obrigatório", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.DuolingoUsername(user); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	userData, err := tools.FetchDuolingoUser(user)
+	key := cache.Key("duolingo", "user", user, nil)
+	userData, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (tools.User, error) {
+		return tools.FetchDuolingoUser(ctx, user)
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Write(w, apierror.FromUpstream("duolingo", err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(userData)
+	cache.WriteJSON(w, r, userData, result)
 }
 
 
@@ -1,10 +1,15 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"sort"
+	"time"
+
+	"api_git_leet_duo/api/upstream"
 )
 
 type DuolingoResponse struct {
@@ -48,21 +53,39 @@ type Course struct {
 	ID              string `json:"id"`
 }
 
-func FetchDuolingoUser(user string) (User, error) {
-	url := fmt.Sprintf("https://www.duolingo.com/2017-06-30/users?username=%s&fields=streak,streakData%%7BcurrentStreak,previousStreak%%7D%%7D", user)
-	resp, err := http.Get(url)
+// httpClient bounds every Duolingo call, on top of the request context.
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+func FetchDuolingoUser(ctx context.Context, user string) (User, error) {
+	url := fmt.Sprintf("https://www.duolingo.com/2017-06-30/users?username=%s&fields=streak,streakData%%7BcurrentStreak,previousStreak%%7D%%7D", neturl.QueryEscape(user))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return User{}, err
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return User{}, err
 	}
 	defer resp.Body.Close()
 
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return User{}, fmt.Errorf("duolingo user %q: %w", user, upstream.ErrUserNotFound)
+	case http.StatusTooManyRequests:
+		return User{}, fmt.Errorf("duolingo: %w", upstream.ErrRateLimited)
+	default:
+		return User{}, fmt.Errorf("duolingo: unexpected status %d", resp.StatusCode)
+	}
+
 	var data DuolingoResponse
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return User{}, err
 	}
 
 	if len(data.Users) == 0 {
-		return User{}, fmt.Errorf("usuário não encontrado")
+		return User{}, fmt.Errorf("duolingo user %q: %w", user, upstream.ErrUserNotFound)
 	}
 
 	userData := data.Users[0]
@@ -0,0 +1,265 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultCellSize = 11
+	MinCellSize     = 4
+	MaxCellSize     = 32
+
+	heatmapPadding = 12
+	// heatmapMinWidth leaves room for the title and legend on short ranges.
+	heatmapMinWidth = 320
+	dateLayout      = "2006-01-02"
+)
+
+// Palettes are the heatmap color scales selectable with ?palette=, from the
+// empty cell to the busiest one.
+var Palettes = map[string][5]string{
+	"github":      {"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"},
+	"github_dark": {"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"},
+	"halloween":   {"#ebedf0", "#ffee4a", "#ffc501", "#fe9600", "#03001c"},
+	"blue":        {"#ebedf0", "#c6e3ff", "#79b8ff", "#2188ff", "#005cc5"},
+	"purple":      {"#ebedf0", "#e0d4f7", "#b392f0", "#8a63d2", "#5a32a3"},
+	"orange":      {"#ebedf0", "#ffdf9e", "#ffb347", "#fb8c00", "#c25e00"},
+}
+
+// darkThemes get the github_dark palette when no palette is requested.
+var darkThemes = map[string]bool{"dark": true, "radical": true, "tokyonight": true, "github_dark": true}
+
+// HeatmapData is the calendar drawn on a heatmap: a count per YYYY-MM-DD
+// date, drawn from From to To inclusive.
+type HeatmapData struct {
+	Title  string
+	From   string
+	To     string
+	Counts map[string]int
+}
+
+// HeatmapOptions controls how the heatmap is painted.
+type HeatmapOptions struct {
+	Theme    Theme
+	Palette  [5]string
+	Locale   Locale
+	CellSize int
+	// Hidden may contain "title", "total", "months", "days", "legend" and "border".
+	Hidden map[string]bool
+}
+
+// ParsePalette picks the palette named by ?palette=, or five comma separated
+// hex ?colors= from empty to busiest. Without either, dark themes get
+// github_dark and the others github.
+func ParsePalette(q url.Values) [5]string {
+	if colors := strings.Split(q.Get("colors"), ","); len(colors) == 5 {
+		var palette [5]string
+		valid := true
+		for i, color := range colors {
+			color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+			if !hexColor.MatchString(color) {
+				valid = false
+				break
+			}
+			palette[i] = "#" + color
+		}
+		if valid {
+			return palette
+		}
+	}
+	if palette, ok := Palettes[strings.ToLower(q.Get("palette"))]; ok {
+		return palette
+	}
+	if darkThemes[strings.ToLower(q.Get("theme"))] {
+		return Palettes["github_dark"]
+	}
+	return Palettes["github"]
+}
+
+// ParseCellSize reads ?cell_size=, in pixels.
+func ParseCellSize(q url.Values) (int, error) {
+	value := q.Get("cell_size")
+	if value == "" {
+		return DefaultCellSize, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < MinCellSize || size > MaxCellSize {
+		return 0, fmt.Errorf("must be a number between %d and %d", MinCellSize, MaxCellSize)
+	}
+	return size, nil
+}
+
+// ParseHeatmapRange reads ?year=. A past year spans January to December and
+// the current year ends today; without a year the range is the last 365
+// days ending today.
+func ParseHeatmapRange(q url.Values, today time.Time) (from, to string, err error) {
+	value := q.Get("year")
+	if value == "" {
+		return today.AddDate(0, 0, -364).Format(dateLayout), today.Format(dateLayout), nil
+	}
+
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 2000 || year > today.Year() {
+		return "", "", fmt.Errorf("must be a year between 2000 and %d", today.Year())
+	}
+	from = fmt.Sprintf("%d-01-01", year)
+	to = fmt.Sprintf("%d-12-31", year)
+	if year == today.Year() {
+		to = today.Format(dateLayout)
+	}
+	return from, to, nil
+}
+
+// heatmapLevel maps count to a palette index, in quarters of max.
+func heatmapLevel(count, max int) int {
+	if count <= 0 || max <= 0 {
+		return 0
+	}
+	level := (count*4 + max - 1) / max
+	if level > 4 {
+		level = 4
+	}
+	return level
+}
+
+// RenderHeatmap renders a GitHub-style calendar heatmap as an SVG document:
+// one column per week, one row per weekday starting on Sunday.
+func RenderHeatmap(data HeatmapData, opts HeatmapOptions) (string, error) {
+	start, err := time.Parse(dateLayout, data.From)
+	if err != nil {
+		return "", err
+	}
+	end, err := time.Parse(dateLayout, data.To)
+	if err != nil {
+		return "", err
+	}
+	if end.Before(start) {
+		return "", errors.New("heatmap range ends before it starts")
+	}
+
+	cell := opts.CellSize
+	if cell == 0 {
+		cell = DefaultCellSize
+	}
+	gap := cell / 5
+	if gap < 1 {
+		gap = 1
+	}
+	step := cell + gap
+	fontSize := cell
+	if fontSize < 9 {
+		fontSize = 9
+	} else if fontSize > 14 {
+		fontSize = 14
+	}
+
+	gridStart := start.AddDate(0, 0, -int(start.Weekday()))
+	weeks := int(end.Sub(gridStart).Hours()/24)/7 + 1
+
+	total, busiest := 0, 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		count := data.Counts[d.Format(dateLayout)]
+		total += count
+		if count > busiest {
+			busiest = count
+		}
+	}
+
+	titleH, monthH, dayW, legendH := 0, 0, 0, 0
+	if !opts.Hidden["title"] || !opts.Hidden["total"] {
+		titleH = fontSize + 14
+	}
+	if !opts.Hidden["months"] {
+		monthH = fontSize + 4
+	}
+	if !opts.Hidden["days"] {
+		dayW = fontSize * 3
+	}
+	if !opts.Hidden["legend"] {
+		legendH = step + 8
+	}
+
+	width := 2*heatmapPadding + dayW + weeks*step - gap
+	if width < heatmapMinWidth {
+		width = heatmapMinWidth
+	}
+	height := 2*heatmapPadding + titleH + monthH + 7*step - gap + legendH
+	gridX := heatmapPadding + dayW
+	gridY := heatmapPadding + titleH + monthH
+
+	t := opts.Theme
+	l := opts.Locale
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&b, `<style>.title{font:600 %dpx 'Segoe UI',Ubuntu,sans-serif}.label{font:400 %dpx 'Segoe UI',Ubuntu,sans-serif}</style>`, fontSize+2, fontSize)
+
+	stroke := t.Border
+	if opts.Hidden["border"] {
+		stroke = "none"
+	}
+	fmt.Fprintf(&b, `<rect x="0.5" y="0.5" rx="4.5" width="%d" height="%d" fill="%s" stroke="%s"/>`, width-1, height-1, t.Background, stroke)
+
+	if titleH > 0 {
+		y := heatmapPadding + fontSize + 2
+		if !opts.Hidden["title"] {
+			fmt.Fprintf(&b, `<text class="title" x="%d" y="%d" fill="%s">%s</text>`, heatmapPadding, y, t.Title, html.EscapeString(data.Title))
+		}
+		if !opts.Hidden["total"] {
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end" fill="%s">%s</text>`, width-heatmapPadding, y, t.Text, html.EscapeString(formatNumber(total)+" "+l.TotalContributions))
+		}
+	}
+
+	if monthH > 0 {
+		lastCol := -3
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			if d.Day() != 1 && !(d.Equal(start) && d.Day() <= 20) {
+				continue
+			}
+			col := int(d.Sub(gridStart).Hours()/24) / 7
+			if d.Weekday() != time.Sunday && !d.Equal(start) {
+				col++
+			}
+			if col-lastCol < 3 || col >= weeks {
+				continue
+			}
+			lastCol = col
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" fill="%s">%s</text>`, gridX+col*step, gridY-4, t.Muted, html.EscapeString(l.Months[d.Month()-1]))
+		}
+	}
+
+	if dayW > 0 {
+		for _, row := range []int{1, 3, 5} {
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" fill="%s">%s</text>`, heatmapPadding, gridY+row*step+cell-1, t.Muted, html.EscapeString(l.Weekdays[row]))
+		}
+	}
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		count := data.Counts[date]
+		col := int(d.Sub(gridStart).Hours()/24) / 7
+		row := int(d.Weekday())
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%d · %s</title></rect>`,
+			gridX+col*step, gridY+row*step, cell, cell, opts.Palette[heatmapLevel(count, busiest)], count, html.EscapeString(l.FormatDate(date)))
+	}
+
+	if legendH > 0 {
+		y := gridY + 7*step - gap + 8
+		charW := fontSize * 6 / 10
+		x := width - heatmapPadding - len([]rune(l.More))*charW
+		fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" fill="%s">%s</text>`, x, y+cell-1, t.Muted, html.EscapeString(l.More))
+		x -= gap + 5*step
+		for i, color := range opts.Palette {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`, x+i*step, y, cell, cell, color)
+		}
+		fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end" fill="%s">%s</text>`, x-gap-2, y+cell-1, t.Muted, html.EscapeString(l.Less))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String(), nil
+}
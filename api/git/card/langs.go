@@ -0,0 +1,183 @@
+package card
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+const (
+	langsCardWidth   = 350
+	langsCardPadding = 25
+	// DefaultLangsCount is how many languages are drawn before "Other".
+	DefaultLangsCount = 6
+	MaxLangsCount     = 20
+)
+
+// Layouts of the languages card, selectable with ?layout=.
+const (
+	LayoutBar   = "bar"
+	LayoutDonut = "donut"
+	LayoutList  = "list"
+)
+
+// LangStat is one language and its share of the total, in percent.
+type LangStat struct {
+	Name       string
+	Percentage float64
+}
+
+// LangsCardOptions controls how the languages card is painted.
+type LangsCardOptions struct {
+	Theme  Theme
+	Locale Locale
+	Layout string
+	// Hidden may contain "title", "percent" and "border".
+	Hidden map[string]bool
+}
+
+// TopLanguages keeps the n largest languages of langs, which must be sorted
+// by percentage, and sums the rest into an "Other" entry.
+func TopLanguages(langs []LangStat, n int, other string) []LangStat {
+	if n <= 0 || len(langs) <= n {
+		return langs
+	}
+	top := make([]LangStat, n, n+1)
+	copy(top, langs[:n])
+
+	rest := 0.0
+	for _, lang := range langs[n:] {
+		rest += lang.Percentage
+	}
+	return append(top, LangStat{Name: other, Percentage: rest})
+}
+
+// RenderLangsCard renders the languages card as an SVG document.
+func RenderLangsCard(langs []LangStat, opts LangsCardOptions) string {
+	t := opts.Theme
+	l := opts.Locale
+
+	top := langsCardPadding
+	if !opts.Hidden["title"] {
+		top += 30
+	}
+
+	var body strings.Builder
+	var height int
+	switch opts.Layout {
+	case LayoutDonut:
+		height = renderLangsDonut(&body, langs, opts, top)
+	case LayoutList:
+		height = renderLangsList(&body, langs, opts, top)
+	default:
+		height = renderLangsBar(&body, langs, opts, top)
+	}
+	height += langsCardPadding
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, langsCardWidth, height, langsCardWidth, height)
+	b.WriteString(`<style>.title{font:600 18px 'Segoe UI',Ubuntu,sans-serif}.lang{font:400 12px 'Segoe UI',Ubuntu,sans-serif}</style>`)
+
+	stroke := t.Border
+	if opts.Hidden["border"] {
+		stroke = "none"
+	}
+	fmt.Fprintf(&b, `<rect x="0.5" y="0.5" rx="4.5" width="%d" height="%d" fill="%s" stroke="%s"/>`, langsCardWidth-1, height-1, t.Background, stroke)
+	if !opts.Hidden["title"] {
+		fmt.Fprintf(&b, `<text class="title" x="%d" y="%d" fill="%s">%s</text>`, langsCardPadding, langsCardPadding+10, t.Title, html.EscapeString(l.MostUsedLanguages))
+	}
+	b.WriteString(body.String())
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// langLabel is the legend text of lang, with its percentage unless hidden.
+func langLabel(lang LangStat, opts LangsCardOptions) string {
+	if opts.Hidden["percent"] {
+		return lang.Name
+	}
+	return fmt.Sprintf("%s %.2f%%", lang.Name, lang.Percentage)
+}
+
+// langColor paints the "Other" bucket with the muted theme color.
+func langColor(lang LangStat, opts LangsCardOptions) string {
+	if lang.Name == opts.Locale.Other {
+		return opts.Theme.Muted
+	}
+	return LanguageColor(lang.Name)
+}
+
+// renderLangsBar draws one stacked bar and a two-column legend, returning
+// the y where it ends.
+func renderLangsBar(b *strings.Builder, langs []LangStat, opts LangsCardOptions, y int) int {
+	barWidth := float64(langsCardWidth - 2*langsCardPadding)
+	fmt.Fprintf(b, `<clipPath id="bar"><rect x="%d" y="%d" width="%.0f" height="8" rx="4"/></clipPath>`, langsCardPadding, y, barWidth)
+	b.WriteString(`<g clip-path="url(#bar)">`)
+	x := float64(langsCardPadding)
+	for _, lang := range langs {
+		w := barWidth * lang.Percentage / 100
+		fmt.Fprintf(b, `<rect x="%.2f" y="%d" width="%.2f" height="8" fill="%s"/>`, x, y, w, langColor(lang, opts))
+		x += w
+	}
+	b.WriteString(`</g>`)
+
+	y += 30
+	colWidth := (langsCardWidth - 2*langsCardPadding) / 2
+	for i, lang := range langs {
+		cx := langsCardPadding + (i%2)*colWidth
+		cy := y + (i/2)*22
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="5" fill="%s"/>`, cx+5, cy-4, langColor(lang, opts))
+		fmt.Fprintf(b, `<text class="lang" x="%d" y="%d" fill="%s">%s</text>`, cx+15, cy, opts.Theme.Text, html.EscapeString(langLabel(lang, opts)))
+	}
+	return y + ((len(langs)+1)/2-1)*22
+}
+
+// renderLangsDonut draws a donut chart with the legend on its right,
+// returning the y where it ends.
+func renderLangsDonut(b *strings.Builder, langs []LangStat, opts LangsCardOptions, y int) int {
+	const radius, thickness = 45.0, 18.0
+	cx := float64(langsCardPadding) + radius + thickness/2
+	cy := float64(y) + radius + thickness/2
+	circumference := 2 * math.Pi * radius
+
+	fmt.Fprintf(b, `<g transform="rotate(-90 %.2f %.2f)">`, cx, cy)
+	offset := 0.0
+	for _, lang := range langs {
+		length := circumference * lang.Percentage / 100
+		fmt.Fprintf(b, `<circle cx="%.2f" cy="%.2f" r="%.0f" fill="none" stroke="%s" stroke-width="%.0f" stroke-dasharray="%.2f %.2f" stroke-dashoffset="%.2f"/>`,
+			cx, cy, radius, langColor(lang, opts), thickness, length, circumference-length, -offset)
+		offset += length
+	}
+	b.WriteString(`</g>`)
+
+	x := int(cx + radius + thickness/2 + 25)
+	ly := y + 12
+	for i, lang := range langs {
+		row := ly + i*20
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="5" fill="%s"/>`, x+5, row-4, langColor(lang, opts))
+		fmt.Fprintf(b, `<text class="lang" x="%d" y="%d" fill="%s">%s</text>`, x+15, row, opts.Theme.Text, html.EscapeString(langLabel(lang, opts)))
+	}
+
+	end := int(cy + radius + thickness/2)
+	if legendEnd := ly + (len(langs)-1)*20 + 6; legendEnd > end {
+		end = legendEnd
+	}
+	return end
+}
+
+// renderLangsList draws one row per language with its own bar, returning
+// the y where it ends.
+func renderLangsList(b *strings.Builder, langs []LangStat, opts LangsCardOptions, y int) int {
+	barWidth := float64(langsCardWidth - 2*langsCardPadding)
+	for i, lang := range langs {
+		row := y + 10 + i*36
+		fmt.Fprintf(b, `<text class="lang" x="%d" y="%d" fill="%s">%s</text>`, langsCardPadding, row, opts.Theme.Text, html.EscapeString(lang.Name))
+		if !opts.Hidden["percent"] {
+			fmt.Fprintf(b, `<text class="lang" x="%d" y="%d" text-anchor="end" fill="%s">%.2f%%</text>`, langsCardWidth-langsCardPadding, row, opts.Theme.Muted, lang.Percentage)
+		}
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%.0f" height="8" rx="4" fill="%s" opacity="0.25"/>`, langsCardPadding, row+8, barWidth, opts.Theme.Muted)
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%.2f" height="8" rx="4" fill="%s"/>`, langsCardPadding, row+8, barWidth*lang.Percentage/100, langColor(lang, opts))
+	}
+	return y + len(langs)*36
+}
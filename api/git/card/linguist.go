@@ -0,0 +1,124 @@
+package card
+
+import "strings"
+
+// defaultLanguageColor paints languages missing from LanguageColors.
+const defaultLanguageColor = "#858585"
+
+// LanguageColors maps GitHub linguist language names to their colors, as
+// shown on repository language bars.
+var LanguageColors = map[string]string{
+	"ABAP":              "#E8274B",
+	"ActionScript":      "#882B0F",
+	"Ada":               "#02f88c",
+	"Apex":              "#1797c0",
+	"AppleScript":       "#101F1F",
+	"Arduino":           "#bd79d1",
+	"Assembly":          "#6E4C13",
+	"Astro":             "#ff5a03",
+	"AutoHotkey":        "#6594b9",
+	"Batchfile":         "#C1F12E",
+	"Bicep":             "#519aba",
+	"C":                 "#555555",
+	"C#":                "#178600",
+	"C++":               "#f34b7d",
+	"Clojure":           "#db5855",
+	"CMake":             "#DA3434",
+	"COBOL":             "#005ca5",
+	"CoffeeScript":      "#244776",
+	"Common Lisp":       "#3fb68b",
+	"Crystal":           "#000100",
+	"CSS":               "#563d7c",
+	"Cuda":              "#3A4E3A",
+	"D":                 "#ba595e",
+	"Dart":              "#00B4AB",
+	"Dockerfile":        "#384d54",
+	"Elixir":            "#6e4a7e",
+	"Elm":               "#60B5CC",
+	"Emacs Lisp":        "#c065db",
+	"Erlang":            "#B83998",
+	"F#":                "#b845fc",
+	"Fortran":           "#4d41b1",
+	"GDScript":          "#355570",
+	"GLSL":              "#5686a5",
+	"Go":                "#00ADD8",
+	"Groovy":            "#4298b8",
+	"Hack":              "#878787",
+	"Handlebars":        "#f7931e",
+	"Haskell":           "#5e5086",
+	"HCL":               "#844FBA",
+	"HTML":              "#e34c26",
+	"Java":              "#b07219",
+	"JavaScript":        "#f1e05a",
+	"Jinja":             "#a52a22",
+	"Julia":             "#a270ba",
+	"Jupyter Notebook":  "#DA5B0B",
+	"Kotlin":            "#A97BFF",
+	"LaTeX":             "#3D6117",
+	"Less":              "#1d365d",
+	"Lua":               "#000080",
+	"Makefile":          "#427819",
+	"Markdown":          "#083fa1",
+	"MATLAB":            "#e16737",
+	"MDX":               "#fcb32c",
+	"Nim":               "#ffc200",
+	"Nix":               "#7e7eff",
+	"Objective-C":       "#438eff",
+	"Objective-C++":     "#6866fb",
+	"OCaml":             "#ef7a08",
+	"Pascal":            "#E3F171",
+	"Perl":              "#0298c3",
+	"PHP":               "#4F5D95",
+	"PLpgSQL":           "#336790",
+	"PowerShell":        "#012456",
+	"Processing":        "#0096D8",
+	"Prolog":            "#74283c",
+	"Pug":               "#a86454",
+	"PureScript":        "#1D222D",
+	"Python":            "#3572A5",
+	"QML":               "#44a51c",
+	"R":                 "#198CE7",
+	"Racket":            "#3c5caa",
+	"Raku":              "#0000fb",
+	"ReScript":          "#ed5051",
+	"Roff":              "#ecdebe",
+	"Ruby":              "#701516",
+	"Rust":              "#dea584",
+	"Sass":              "#a53b70",
+	"Scala":             "#c22d40",
+	"Scheme":            "#1e4aec",
+	"SCSS":              "#c6538c",
+	"Shell":             "#89e051",
+	"Smalltalk":         "#596706",
+	"Solidity":          "#AA6746",
+	"SQL":               "#e38c00",
+	"Svelte":            "#ff3e00",
+	"Swift":             "#F05138",
+	"SystemVerilog":     "#DAE1C2",
+	"Tcl":               "#e4cc98",
+	"TeX":               "#3D6117",
+	"TSQL":              "#e38c00",
+	"TypeScript":        "#3178c6",
+	"Vala":              "#a56de2",
+	"VBA":               "#867db1",
+	"Verilog":           "#b2b7f8",
+	"VHDL":              "#adb2cb",
+	"Vim Script":        "#199f4b",
+	"Visual Basic .NET": "#945db7",
+	"Vue":               "#41b883",
+	"WebAssembly":       "#04133b",
+	"Zig":               "#ec915c",
+}
+
+// LanguageColor returns the linguist color of lang, matched case-insensitively.
+func LanguageColor(lang string) string {
+	if color, ok := LanguageColors[lang]; ok {
+		return color
+	}
+	for name, color := range LanguageColors {
+		if strings.EqualFold(name, lang) {
+			return color
+		}
+	}
+	return defaultLanguageColor
+}
@@ -0,0 +1,123 @@
+package card
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Locale holds the labels and month names used on a card.
+type Locale struct {
+	TotalContributions string
+	CurrentStreak      string
+	LongestStreak      string
+	Present            string
+	Months             [12]string
+	// Weekdays starts on Sunday.
+	Weekdays [7]string
+	Less     string
+	More     string
+	// MostUsedLanguages titles the languages card; Other buckets the
+	// languages past the top N.
+	MostUsedLanguages string
+	Other             string
+	// CommitsByHour titles the punch card.
+	CommitsByHour string
+	// DayFirst renders dates as "2 Jan 2006" instead of "Jan 2, 2006".
+	DayFirst bool
+}
+
+// Locales are the translations selectable with ?locale=.
+var Locales = map[string]Locale{
+	"en": {
+		TotalContributions: "Total Contributions",
+		CurrentStreak:      "Current Streak",
+		LongestStreak:      "Longest Streak",
+		Present:            "Present",
+		Months:             [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:           [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Less:               "Less",
+		More:               "More",
+		MostUsedLanguages:  "Most Used Languages",
+		Other:              "Other",
+		CommitsByHour:      "Commits by Hour",
+	},
+	"pt": {
+		TotalContributions: "Contribuições Totais",
+		CurrentStreak:      "Sequência Atual",
+		LongestStreak:      "Maior Sequência",
+		Present:            "Presente",
+		Months:             [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays:           [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		Less:               "Menos",
+		More:               "Mais",
+		MostUsedLanguages:  "Linguagens Mais Usadas",
+		Other:              "Outras",
+		CommitsByHour:      "Commits por Hora",
+		DayFirst:           true,
+	},
+	"es": {
+		TotalContributions: "Contribuciones Totales",
+		CurrentStreak:      "Racha Actual",
+		LongestStreak:      "Racha Más Larga",
+		Present:            "Presente",
+		Months:             [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:           [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		Less:               "Menos",
+		More:               "Más",
+		MostUsedLanguages:  "Lenguajes Más Usados",
+		Other:              "Otros",
+		CommitsByHour:      "Commits por Hora",
+		DayFirst:           true,
+	},
+}
+
+// LookupLocale returns the locale for a code such as "pt" or "pt-BR",
+// falling back to English.
+func LookupLocale(code string) Locale {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if locale, ok := Locales[code]; ok {
+		return locale
+	}
+	return Locales["en"]
+}
+
+// FormatDate formats a YYYY-MM-DD date. The year is omitted when it matches
+// the current year, as GitHub does on profile pages.
+func (l Locale) FormatDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	month := l.Months[t.Month()-1]
+	withYear := t.Year() != time.Now().Year()
+
+	if l.DayFirst {
+		if withYear {
+			return fmt.Sprintf("%d %s %d", t.Day(), month, t.Year())
+		}
+		return fmt.Sprintf("%d %s", t.Day(), month)
+	}
+	if withYear {
+		return fmt.Sprintf("%s %d, %d", month, t.Day(), t.Year())
+	}
+	return fmt.Sprintf("%s %d", month, t.Day())
+}
+
+// FormatRange formats a start/end pair. An empty end means the range is
+// still open.
+func (l Locale) FormatRange(start, end string) string {
+	if start == "" {
+		return ""
+	}
+	if end == "" {
+		return l.FormatDate(start) + " - " + l.Present
+	}
+	if start == end {
+		return l.FormatDate(start)
+	}
+	return l.FormatDate(start) + " - " + l.FormatDate(end)
+}
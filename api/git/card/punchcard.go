@@ -0,0 +1,100 @@
+package card
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+const (
+	punchcardPadding = 20
+	punchcardCell    = 24
+	punchcardDayW    = 40
+)
+
+// PunchcardOptions controls how the punch card is painted.
+type PunchcardOptions struct {
+	Theme  Theme
+	Locale Locale
+	// Hidden may contain "title", "total", "days", "hours" and "border".
+	Hidden map[string]bool
+}
+
+// RenderPunchcard renders commit counts by weekday, Sunday first, and hour
+// as an SVG punch card: one row per weekday, one column per hour, with
+// circle areas proportional to the counts.
+func RenderPunchcard(counts [7][24]int, opts PunchcardOptions) string {
+	t := opts.Theme
+	l := opts.Locale
+
+	total, busiest := 0, 0
+	for _, hours := range counts {
+		for _, count := range hours {
+			total += count
+			busiest = max(busiest, count)
+		}
+	}
+
+	titleH, dayW, hourH := 0, 0, 0
+	if !opts.Hidden["title"] || !opts.Hidden["total"] {
+		titleH = 30
+	}
+	if !opts.Hidden["days"] {
+		dayW = punchcardDayW
+	}
+	if !opts.Hidden["hours"] {
+		hourH = 18
+	}
+
+	width := 2*punchcardPadding + dayW + 24*punchcardCell
+	height := 2*punchcardPadding + titleH + 7*punchcardCell + hourH
+	gridX := punchcardPadding + dayW
+	gridY := punchcardPadding + titleH
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	b.WriteString(`<style>.title{font:600 16px 'Segoe UI',Ubuntu,sans-serif}.label{font:400 11px 'Segoe UI',Ubuntu,sans-serif}</style>`)
+
+	stroke := t.Border
+	if opts.Hidden["border"] {
+		stroke = "none"
+	}
+	fmt.Fprintf(&b, `<rect x="0.5" y="0.5" rx="4.5" width="%d" height="%d" fill="%s" stroke="%s"/>`, width-1, height-1, t.Background, stroke)
+
+	if titleH > 0 {
+		y := punchcardPadding + 14
+		if !opts.Hidden["title"] {
+			fmt.Fprintf(&b, `<text class="title" x="%d" y="%d" fill="%s">%s</text>`, punchcardPadding, y, t.Title, html.EscapeString(l.CommitsByHour))
+		}
+		if !opts.Hidden["total"] {
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="end" fill="%s">%s</text>`, width-punchcardPadding, y, t.Text, formatNumber(total))
+		}
+	}
+
+	for day, hours := range counts {
+		cy := gridY + day*punchcardCell + punchcardCell/2
+		if dayW > 0 {
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" fill="%s">%s</text>`, punchcardPadding, cy+4, t.Muted, html.EscapeString(l.Weekdays[day]))
+		}
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-opacity="0.3"/>`, gridX, cy, gridX+24*punchcardCell, cy, t.Muted)
+		for hour, count := range hours {
+			if count == 0 {
+				continue
+			}
+			r := float64(punchcardCell-4) / 2 * math.Sqrt(float64(count)/float64(busiest))
+			cx := gridX + hour*punchcardCell + punchcardCell/2
+			fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%.2f" fill="%s"><title>%d</title></circle>`, cx, cy, math.Max(r, 1.5), t.Accent, count)
+		}
+	}
+
+	if hourH > 0 {
+		y := gridY + 7*punchcardCell + 14
+		for hour := 0; hour < 24; hour += 3 {
+			fmt.Fprintf(&b, `<text class="label" x="%d" y="%d" text-anchor="middle" fill="%s">%02d</text>`, gridX+hour*punchcardCell+punchcardCell/2, y, t.Muted, hour)
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
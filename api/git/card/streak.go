@@ -0,0 +1,110 @@
+package card
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	streakCardWidth  = 495
+	streakCardHeight = 195
+)
+
+// StreakStat is a streak length with the dates it spans. An empty End marks
+// a streak that is still running.
+type StreakStat struct {
+	Length int
+	Start  string
+	End    string
+}
+
+// StreakCardData is everything drawn on the streak card.
+type StreakCardData struct {
+	Total             int
+	FirstContribution string
+	Current           StreakStat
+	Longest           StreakStat
+}
+
+// StreakCardOptions controls how the streak card is painted.
+type StreakCardOptions struct {
+	Theme  Theme
+	Locale Locale
+	// Hidden may contain "total", "current", "longest" and "border".
+	Hidden map[string]bool
+}
+
+type streakColumn struct {
+	key    string
+	value  string
+	label  string
+	dates  string
+	accent bool
+}
+
+// RenderStreakCard renders the streak card as an SVG document.
+func RenderStreakCard(data StreakCardData, opts StreakCardOptions) string {
+	l := opts.Locale
+
+	columns := []streakColumn{
+		{key: "total", value: formatNumber(data.Total), label: l.TotalContributions, dates: l.FormatRange(data.FirstContribution, "")},
+		{key: "current", value: formatNumber(data.Current.Length), label: l.CurrentStreak, dates: l.FormatRange(data.Current.Start, data.Current.End), accent: true},
+		{key: "longest", value: formatNumber(data.Longest.Length), label: l.LongestStreak, dates: l.FormatRange(data.Longest.Start, data.Longest.End)},
+	}
+	visible := columns[:0]
+	for _, c := range columns {
+		if !opts.Hidden[c.key] {
+			visible = append(visible, c)
+		}
+	}
+
+	t := opts.Theme
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, streakCardWidth, streakCardHeight, streakCardWidth, streakCardHeight)
+	b.WriteString(`<style>.value{font:700 28px 'Segoe UI',Ubuntu,sans-serif}.label{font:400 14px 'Segoe UI',Ubuntu,sans-serif}.dates{font:400 12px 'Segoe UI',Ubuntu,sans-serif}</style>`)
+
+	stroke := t.Border
+	if opts.Hidden["border"] {
+		stroke = "none"
+	}
+	fmt.Fprintf(&b, `<rect x="0.5" y="0.5" rx="4.5" width="%d" height="%d" fill="%s" stroke="%s"/>`, streakCardWidth-1, streakCardHeight-1, t.Background, stroke)
+
+	if len(visible) == 0 {
+		b.WriteString(`</svg>`)
+		return b.String()
+	}
+
+	colWidth := float64(streakCardWidth) / float64(len(visible))
+	for i, c := range visible {
+		x := colWidth*float64(i) + colWidth/2
+		if i > 0 {
+			fmt.Fprintf(&b, `<line x1="%.1f" y1="28" x2="%.1f" y2="170" stroke="%s" stroke-width="1"/>`, colWidth*float64(i), colWidth*float64(i), t.Border)
+		}
+
+		if c.accent {
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="71" r="40" fill="none" stroke="%s" stroke-width="5"/>`, x, t.Accent)
+			fmt.Fprintf(&b, `<text class="value" x="%.1f" y="80" text-anchor="middle" fill="%s">%s</text>`, x, t.Title, html.EscapeString(c.value))
+			fmt.Fprintf(&b, `<text class="label" x="%.1f" y="140" text-anchor="middle" fill="%s">%s</text>`, x, t.Accent, html.EscapeString(c.label))
+		} else {
+			fmt.Fprintf(&b, `<text class="value" x="%.1f" y="80" text-anchor="middle" fill="%s">%s</text>`, x, t.Title, html.EscapeString(c.value))
+			fmt.Fprintf(&b, `<text class="label" x="%.1f" y="140" text-anchor="middle" fill="%s">%s</text>`, x, t.Text, html.EscapeString(c.label))
+		}
+		fmt.Fprintf(&b, `<text class="dates" x="%.1f" y="162" text-anchor="middle" fill="%s">%s</text>`, x, t.Muted, html.EscapeString(c.dates))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// formatNumber renders n with thousands separators.
+func formatNumber(n int) string {
+	s := fmt.Sprintf("%d", n)
+	if n < 0 {
+		return "-" + formatNumber(-n)
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
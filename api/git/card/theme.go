@@ -0,0 +1,102 @@
+package card
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Theme holds the colors used to paint a card.
+type Theme struct {
+	Background string
+	Border     string
+	Title      string
+	Text       string
+	Accent     string
+	Muted      string
+}
+
+// Themes are the built-in palettes selectable with ?theme=.
+var Themes = map[string]Theme{
+	"default": {
+		Background: "#fffefe",
+		Border:     "#e4e2e2",
+		Title:      "#2f80ed",
+		Text:       "#434d58",
+		Accent:     "#fb8c00",
+		Muted:      "#9e9e9e",
+	},
+	"dark": {
+		Background: "#151515",
+		Border:     "#e4e2e2",
+		Title:      "#fb8c00",
+		Text:       "#fefefe",
+		Accent:     "#fb8c00",
+		Muted:      "#9e9e9e",
+	},
+	"radical": {
+		Background: "#141321",
+		Border:     "#e4e2e2",
+		Title:      "#fe428e",
+		Text:       "#a9fef7",
+		Accent:     "#f8d847",
+		Muted:      "#a9fef7",
+	},
+	"tokyonight": {
+		Background: "#1a1b27",
+		Border:     "#e4e2e2",
+		Title:      "#70a5fd",
+		Text:       "#38bdae",
+		Accent:     "#bf91f3",
+		Muted:      "#38bdae",
+	},
+	"github_dark": {
+		Background: "#0d1117",
+		Border:     "#30363d",
+		Title:      "#58a6ff",
+		Text:       "#c9d1d9",
+		Accent:     "#39d353",
+		Muted:      "#8b949e",
+	},
+}
+
+var hexColor = regexp.MustCompile(`^[0-9a-fA-F]{3,8}$`)
+
+// ParseTheme picks the theme named by ?theme= and applies per-color overrides
+// (bg_color, border_color, title_color, text_color, accent_color, muted_color).
+// Colors are hex values without the leading '#'; invalid values are ignored.
+func ParseTheme(q url.Values) Theme {
+	theme, ok := Themes[strings.ToLower(q.Get("theme"))]
+	if !ok {
+		theme = Themes["default"]
+	}
+
+	overrides := map[string]*string{
+		"bg_color":     &theme.Background,
+		"border_color": &theme.Border,
+		"title_color":  &theme.Title,
+		"text_color":   &theme.Text,
+		"accent_color": &theme.Accent,
+		"muted_color":  &theme.Muted,
+	}
+	for param, color := range overrides {
+		value := strings.TrimPrefix(q.Get(param), "#")
+		if hexColor.MatchString(value) {
+			*color = "#" + value
+		}
+	}
+
+	return theme
+}
+
+// ParseHidden reads a comma separated ?hide= list into a lowercase lookup set.
+func ParseHidden(q url.Values) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Split(q.Get("hide"), ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
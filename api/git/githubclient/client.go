@@ -0,0 +1,209 @@
+// Package githubclient is the single GitHub GraphQL client used by every
+// GitHub endpoint of the API.
+package githubclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"api_git_leet_duo/api/config"
+)
+
+const (
+	// DefaultBaseURL is GitHub's GraphQL endpoint.
+	DefaultBaseURL = "https://api.github.com/graphql"
+	// DefaultUserAgent identifies this API to GitHub.
+	DefaultUserAgent = "api-git-leet-duo"
+	// DefaultTimeout bounds a single GitHub request.
+	DefaultTimeout = 20 * time.Second
+)
+
+// Client executes GraphQL queries against GitHub.
+type Client struct {
+	// BaseURL is the GraphQL endpoint. Tests point it at an httptest server.
+	BaseURL    string
+	HTTPClient *http.Client
+	Tokens     TokenProvider
+	UserAgent  string
+}
+
+// New returns a client for the public GitHub API drawing tokens from tokens.
+func New(tokens TokenProvider) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		Tokens:     tokens,
+		UserAgent:  DefaultUserAgent,
+	}
+}
+
+// FromConfig returns a client drawing from a token pool of the GitHub
+// tokens in cfg.
+func FromConfig(cfg config.Config) *Client {
+	return New(NewTokenPool(cfg.GitHubTokens))
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultClient *Client
+)
+
+// Default returns the client built lazily from config.Get. Only serverless
+// handlers, which never run the server's setup, end up using it.
+func Default() *Client {
+	defaultOnce.Do(func() {
+		defaultClient = FromConfig(config.Get())
+	})
+	return defaultClient
+}
+
+type contextKey struct{}
+
+// WithClient returns a copy of ctx carrying c.
+func WithClient(ctx context.Context, c *Client) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the client carried by ctx, falling back to Default.
+func FromContext(ctx context.Context) *Client {
+	if c, ok := ctx.Value(contextKey{}).(*Client); ok {
+		return c
+	}
+	return Default()
+}
+
+type request struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type envelope struct {
+	Errors GraphQLErrors `json:"errors"`
+}
+
+// Query runs query with variables and decodes the whole response body
+// (data and errors) into target. GraphQL errors are returned as
+// GraphQLErrors, HTTP failures as *StatusError or *RateLimitError. When the
+// token provider is a TokenFeedback, a request rejected for a revoked or
+// rate-limited token is retried on another token.
+func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}, target interface{}) error {
+	body, err := json.Marshal(request{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	feedback, _ := c.Tokens.(TokenFeedback)
+	attempts := 1
+	if feedback != nil && feedback.Size() > 1 {
+		attempts = feedback.Size()
+	}
+
+	for attempt := 1; ; attempt++ {
+		token, err := c.Tokens.Token()
+		if err != nil {
+			return err
+		}
+
+		header, err := c.do(ctx, token, body, target)
+		if feedback != nil {
+			feedback.Observe(token, header, err)
+		}
+		if err == nil || attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+		if !IsUnauthorized(err) && !IsRateLimited(err) {
+			return err
+		}
+	}
+}
+
+// do performs a single request with token and returns the response headers.
+func (c *Client) do(ctx context.Context, token string, body []byte, target interface{}) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.Header, err
+	}
+
+	if err := checkStatus(resp, data); err != nil {
+		return resp.Header, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return resp.Header, fmt.Errorf("decoding GitHub response: %w", err)
+	}
+	if len(env.Errors) > 0 {
+		return resp.Header, env.Errors
+	}
+
+	if target == nil {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return resp.Header, fmt.Errorf("decoding GitHub response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// checkStatus turns non-200 responses into typed errors.
+func checkStatus(resp *http.Response, body []byte) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining")
+	retryAfter, hasRetryAfter := headerInt(resp.Header, "Retry-After")
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && ((hasRemaining && remaining == 0) || hasRetryAfter))
+
+	if limited {
+		rl := &RateLimitError{StatusCode: resp.StatusCode}
+		if hasRetryAfter {
+			rl.RetryAfter = time.Duration(retryAfter) * time.Second
+		}
+		if reset, ok := headerInt(resp.Header, "X-RateLimit-Reset"); ok {
+			rl.ResetAt = time.Unix(int64(reset), 0)
+		}
+		return rl
+	}
+
+	var message struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(body, &message)
+	return &StatusError{StatusCode: resp.StatusCode, Message: message.Message}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	value := h.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	return n, err == nil
+}
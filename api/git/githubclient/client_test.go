@@ -0,0 +1,159 @@
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newTestClient points a client drawing from tokens at handler.
+func newTestClient(t *testing.T, tokens TokenProvider, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(tokens)
+	client.BaseURL = server.URL
+	return client
+}
+
+func TestQueryDecodesData(t *testing.T) {
+	client := newTestClient(t, NewStaticTokens([]string{"secret"}), func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != DefaultUserAgent {
+			t.Errorf("User-Agent = %q", got)
+		}
+		w.Write([]byte(`{"data":{"user":{"login":"octocat"}}}`))
+	})
+
+	var target struct {
+		Data struct {
+			User struct {
+				Login string `json:"login"`
+			} `json:"user"`
+		} `json:"data"`
+	}
+	if err := client.Query(context.Background(), "query { user { login } }", nil, &target); err != nil {
+		t.Fatal(err)
+	}
+	if target.Data.User.Login != "octocat" {
+		t.Errorf("login = %q, want octocat", target.Data.User.Login)
+	}
+}
+
+func TestQueryTypedErrors(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name: "GraphQL NOT_FOUND",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"data":{"user":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a User"}]}`))
+			},
+			check: func(t *testing.T, err error) {
+				var gqlErrs GraphQLErrors
+				if !errors.As(err, &gqlErrs) {
+					t.Fatalf("err = %T, want GraphQLErrors", err)
+				}
+				if !IsNotFound(err) {
+					t.Error("IsNotFound = false")
+				}
+			},
+		},
+		{
+			name: "401 unauthorized",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Bad credentials"}`))
+			},
+			check: func(t *testing.T, err error) {
+				if !IsUnauthorized(err) {
+					t.Errorf("IsUnauthorized(%v) = false", err)
+				}
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.Message != "Bad credentials" {
+					t.Errorf("err = %#v, want the GitHub message", err)
+				}
+			},
+		},
+		{
+			name: "403 with no remaining budget",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			check: func(t *testing.T, err error) {
+				var rlErr *RateLimitError
+				if !errors.As(err, &rlErr) {
+					t.Fatalf("err = %T, want *RateLimitError", err)
+				}
+				if rlErr.ResetAt.Unix() != reset {
+					t.Errorf("ResetAt = %s, want %s", rlErr.ResetAt, time.Unix(reset, 0))
+				}
+				if !IsRateLimited(err) {
+					t.Error("IsRateLimited = false")
+				}
+			},
+		},
+		{
+			name: "403 with budget left is not a rate limit",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "10")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			check: func(t *testing.T, err error) {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+					t.Errorf("err = %#v, want a 403 StatusError", err)
+				}
+				if IsRateLimited(err) {
+					t.Error("IsRateLimited = true")
+				}
+			},
+		},
+		{
+			name: "429 with Retry-After",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			check: func(t *testing.T, err error) {
+				var rlErr *RateLimitError
+				if !errors.As(err, &rlErr) || rlErr.RetryAfter != 30*time.Second {
+					t.Errorf("err = %#v, want a RateLimitError retrying after 30s", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, NewStaticTokens([]string{"token"}), tt.handler)
+			err := client.Query(context.Background(), "query { viewer { login } }", nil, nil)
+			if err == nil {
+				t.Fatal("Query succeeded, want an error")
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestQueryWithoutTokens(t *testing.T) {
+	client := newTestClient(t, NewTokenPool(nil), func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent without a token")
+	})
+	if err := client.Query(context.Background(), "query { viewer { login } }", nil, nil); !errors.Is(err, ErrNoToken) {
+		t.Errorf("err = %v, want ErrNoToken", err)
+	}
+}
@@ -0,0 +1,90 @@
+package githubclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GraphQLError is a single entry of the "errors" array of a GraphQL response.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Type    string        `json:"type,omitempty"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// GraphQLErrors is returned when GitHub answers 200 with an "errors" array.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	if len(e) == 0 {
+		return "GitHub GraphQL error"
+	}
+	return e[0].Message
+}
+
+// HasType reports whether any of the errors has the given type, such as
+// "NOT_FOUND" or "RATE_LIMITED".
+func (e GraphQLErrors) HasType(t string) bool {
+	for _, gqlErr := range e {
+		if gqlErr.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusError is returned when GitHub answers with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API error: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API error: status %d: %s", e.StatusCode, e.Message)
+}
+
+// RateLimitError is returned when GitHub refuses a request because the token
+// ran out of budget.
+type RateLimitError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	ResetAt    time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if !e.ResetAt.IsZero() {
+		return fmt.Sprintf("GitHub API rate limit exceeded, resets at %s", e.ResetAt.UTC().Format(time.RFC3339))
+	}
+	return "GitHub API rate limit exceeded"
+}
+
+// IsNotFound reports whether err means the requested user or repository does not exist.
+func IsNotFound(err error) bool {
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		return gqlErrs.HasType("NOT_FOUND")
+	}
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether err is a primary or secondary rate limit.
+func IsRateLimited(err error) bool {
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return true
+	}
+	var gqlErrs GraphQLErrors
+	return errors.As(err, &gqlErrs) && gqlErrs.HasType("RATE_LIMITED")
+}
+
+// IsUnauthorized reports whether GitHub rejected the token.
+func IsUnauthorized(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized
+}
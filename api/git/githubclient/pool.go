@@ -0,0 +1,201 @@
+package githubclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TokenFeedback is implemented by providers that learn from responses, so
+// the client can report rate-limit headers and failures and retry the
+// request on another token.
+type TokenFeedback interface {
+	TokenProvider
+	Observe(token string, header http.Header, err error)
+	Size() int
+}
+
+// TokenPool hands out the token with the most remaining rate-limit budget,
+// skipping tokens that are exhausted until their reset time and tokens that
+// GitHub rejected as revoked.
+type TokenPool struct {
+	mu     sync.Mutex
+	tokens []*tokenState
+	next   int
+}
+
+type tokenState struct {
+	value     string
+	remaining int // -1 while unknown
+	limit     int
+	resetAt   time.Time
+	revoked   bool
+	requests  int
+}
+
+// TokenHealth is the masked, public view of a pooled token.
+type TokenHealth struct {
+	Token     string     `json:"token"`
+	Remaining int        `json:"remaining"`
+	Limit     int        `json:"limit"`
+	ResetAt   *time.Time `json:"reset_at,omitempty"`
+	Exhausted bool       `json:"exhausted"`
+	Revoked   bool       `json:"revoked"`
+	Requests  int        `json:"requests"`
+}
+
+// NewTokenPool returns a pool over tokens, ignoring empty and duplicate values.
+func NewTokenPool(tokens []string) *TokenPool {
+	p := &TokenPool{}
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		p.tokens = append(p.tokens, &tokenState{value: token, remaining: -1})
+	}
+	return p
+}
+
+// Size returns the number of tokens in the pool.
+func (p *TokenPool) Size() int {
+	return len(p.tokens)
+}
+
+// Token returns the usable token with the largest known budget. Tokens with
+// an unknown budget are preferred so they get probed; ties rotate.
+func (p *TokenPool) Token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.tokens) == 0 {
+		return "", ErrNoToken
+	}
+
+	now := time.Now()
+	var best *tokenState
+	var earliestReset time.Time
+	for i := range p.tokens {
+		t := p.tokens[(p.next+i)%len(p.tokens)]
+		if t.revoked {
+			continue
+		}
+		if t.exhausted(now) {
+			if earliestReset.IsZero() || t.resetAt.Before(earliestReset) {
+				earliestReset = t.resetAt
+			}
+			continue
+		}
+		if !t.resetAt.IsZero() && now.After(t.resetAt) {
+			t.remaining = -1
+			t.resetAt = time.Time{}
+		}
+		if best == nil || budget(t) > budget(best) {
+			best = t
+		}
+	}
+	p.next = (p.next + 1) % len(p.tokens)
+
+	if best == nil {
+		if earliestReset.IsZero() {
+			return "", fmt.Errorf("%w: every token was rejected as invalid", ErrNoToken)
+		}
+		return "", &RateLimitError{StatusCode: http.StatusServiceUnavailable, ResetAt: earliestReset, RetryAfter: time.Until(earliestReset)}
+	}
+
+	best.requests++
+	return best.value, nil
+}
+
+// Observe records the rate-limit headers of a response made with token and
+// marks the token revoked or exhausted when err says so.
+func (p *TokenPool) Observe(token string, header http.Header, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := p.find(token)
+	if t == nil {
+		return
+	}
+
+	if remaining, ok := headerInt(header, "X-RateLimit-Remaining"); ok {
+		t.remaining = remaining
+	}
+	if limit, ok := headerInt(header, "X-RateLimit-Limit"); ok {
+		t.limit = limit
+	}
+	if reset, ok := headerInt(header, "X-RateLimit-Reset"); ok {
+		t.resetAt = time.Unix(int64(reset), 0)
+	}
+
+	switch {
+	case IsUnauthorized(err):
+		t.revoked = true
+	case IsRateLimited(err):
+		t.remaining = 0
+		var rlErr *RateLimitError
+		if errors.As(err, &rlErr) && !rlErr.ResetAt.IsZero() {
+			t.resetAt = rlErr.ResetAt
+		}
+		if t.resetAt.IsZero() || t.resetAt.Before(time.Now()) {
+			t.resetAt = time.Now().Add(time.Minute)
+		}
+	}
+}
+
+// Health reports the state of every token with the token value masked.
+func (p *TokenPool) Health() []TokenHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	health := make([]TokenHealth, 0, len(p.tokens))
+	for _, t := range p.tokens {
+		h := TokenHealth{
+			Token:     MaskToken(t.value),
+			Remaining: t.remaining,
+			Limit:     t.limit,
+			Exhausted: t.exhausted(now),
+			Revoked:   t.revoked,
+			Requests:  t.requests,
+		}
+		if !t.resetAt.IsZero() {
+			resetAt := t.resetAt.UTC()
+			h.ResetAt = &resetAt
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+func (p *TokenPool) find(token string) *tokenState {
+	for _, t := range p.tokens {
+		if t.value == token {
+			return t
+		}
+	}
+	return nil
+}
+
+func (t *tokenState) exhausted(now time.Time) bool {
+	return t.remaining == 0 && now.Before(t.resetAt)
+}
+
+// budget orders tokens for selection; an unknown budget ranks first.
+func budget(t *tokenState) int {
+	if t.remaining < 0 {
+		return int(^uint(0) >> 1)
+	}
+	return t.remaining
+}
+
+// MaskToken keeps only the first and last four characters of a token.
+func MaskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	return token[:4] + "…" + token[len(token)-4:]
+}
@@ -0,0 +1,158 @@
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenOf returns the bearer token of r.
+func tokenOf(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// healthOf returns the health entry of the pooled token.
+func healthOf(t *testing.T, p *TokenPool, token string) TokenHealth {
+	t.Helper()
+	for _, h := range p.Health() {
+		if h.Token == MaskToken(token) {
+			return h
+		}
+	}
+	t.Fatalf("token %s not in pool", token)
+	return TokenHealth{}
+}
+
+func TestNewTokenPoolSkipsEmptyAndDuplicates(t *testing.T) {
+	p := NewTokenPool([]string{"token-a", "", "token-b", "token-a"})
+	if p.Size() != 2 {
+		t.Errorf("Size = %d, want 2", p.Size())
+	}
+}
+
+func TestPoolFailsOverRevokedToken(t *testing.T) {
+	pool := NewTokenPool([]string{"revoked-token", "working-token"})
+	var mu sync.Mutex
+	seen := map[string]int{}
+	client := newTestClient(t, pool, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[tokenOf(r)]++
+		mu.Unlock()
+		if tokenOf(r) == "revoked-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	for i := 0; i < 4; i++ {
+		if err := client.Query(context.Background(), "query { viewer { login } }", nil, nil); err != nil {
+			t.Fatalf("query %d: %v", i, err)
+		}
+	}
+
+	if seen["revoked-token"] != 1 {
+		t.Errorf("revoked token used %d times, want once", seen["revoked-token"])
+	}
+	if seen["working-token"] != 4 {
+		t.Errorf("working token used %d times, want 4", seen["working-token"])
+	}
+	if !healthOf(t, pool, "revoked-token").Revoked {
+		t.Error("token not marked revoked")
+	}
+}
+
+func TestPoolFailsOverRateLimitedToken(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	pool := NewTokenPool([]string{"limited-token", "spare-token"})
+	client := newTestClient(t, pool, func(w http.ResponseWriter, r *http.Request) {
+		if tokenOf(r) == "limited-token" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	// Both tokens start unknown; whichever is probed first, every query
+	// must succeed and the limited token must end up exhausted.
+	for i := 0; i < 3; i++ {
+		if err := client.Query(context.Background(), "query { viewer { login } }", nil, nil); err != nil {
+			t.Fatalf("query %d: %v", i, err)
+		}
+	}
+
+	limited := healthOf(t, pool, "limited-token")
+	if !limited.Exhausted || limited.ResetAt == nil || limited.ResetAt.Unix() != reset {
+		t.Errorf("limited token health = %+v, want exhausted until %d", limited, reset)
+	}
+	spare := healthOf(t, pool, "spare-token")
+	if spare.Remaining != 4999 || spare.Limit != 5000 {
+		t.Errorf("spare token health = %+v, want the observed budget", spare)
+	}
+	for i := 0; i < 3; i++ {
+		if token, _ := pool.Token(); token != "spare-token" {
+			t.Errorf("Token() = %s while the other is exhausted", token)
+		}
+	}
+}
+
+func TestPoolPrefersLargestBudget(t *testing.T) {
+	pool := NewTokenPool([]string{"small-token", "large-token"})
+	pool.Observe("small-token", http.Header{"X-Ratelimit-Remaining": {"10"}}, nil)
+	pool.Observe("large-token", http.Header{"X-Ratelimit-Remaining": {"4000"}}, nil)
+
+	for i := 0; i < 3; i++ {
+		if token, _ := pool.Token(); token != "large-token" {
+			t.Errorf("Token() = %s, want the token with the largest budget", token)
+		}
+	}
+}
+
+func TestPoolAllExhausted(t *testing.T) {
+	soon := time.Now().Add(10 * time.Minute)
+	later := time.Now().Add(time.Hour)
+	pool := NewTokenPool([]string{"token-one", "token-two"})
+	pool.Observe("token-one", nil, &RateLimitError{StatusCode: http.StatusForbidden, ResetAt: later})
+	pool.Observe("token-two", nil, &RateLimitError{StatusCode: http.StatusForbidden, ResetAt: soon})
+
+	_, err := pool.Token()
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("err = %v, want *RateLimitError", err)
+	}
+	if rlErr.StatusCode != http.StatusServiceUnavailable || !rlErr.ResetAt.Equal(soon) {
+		t.Errorf("err = %+v, want 503 resetting at the earliest reset", rlErr)
+	}
+}
+
+func TestPoolRevivesTokenAfterReset(t *testing.T) {
+	pool := NewTokenPool([]string{"only-token"})
+	past := strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10)
+	pool.Observe("only-token", http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {past}}, nil)
+
+	token, err := pool.Token()
+	if err != nil || token != "only-token" {
+		t.Errorf("Token() = %q, %v, want the token back once its reset passed", token, err)
+	}
+	if h := healthOf(t, pool, "only-token"); h.Remaining != -1 || h.Exhausted {
+		t.Errorf("health = %+v, want an unknown budget after the reset", h)
+	}
+}
+
+func TestPoolAllRevoked(t *testing.T) {
+	pool := NewTokenPool([]string{"only-token"})
+	pool.Observe("only-token", nil, &StatusError{StatusCode: http.StatusUnauthorized})
+
+	if _, err := pool.Token(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("err = %v, want ErrNoToken", err)
+	}
+}
@@ -0,0 +1,34 @@
+package githubclient
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoToken is returned when no GitHub token is configured.
+var ErrNoToken = errors.New("no GitHub token available")
+
+// TokenProvider supplies the token used for the next request.
+type TokenProvider interface {
+	Token() (string, error)
+}
+
+// StaticTokens rotates round-robin over a fixed list of tokens.
+type StaticTokens struct {
+	tokens []string
+	next   atomic.Uint64
+}
+
+// NewStaticTokens returns a provider rotating over tokens.
+func NewStaticTokens(tokens []string) *StaticTokens {
+	return &StaticTokens{tokens: tokens}
+}
+
+// Token returns the next token in the rotation.
+func (s *StaticTokens) Token() (string, error) {
+	if len(s.tokens) == 0 {
+		return "", ErrNoToken
+	}
+	i := s.next.Add(1) - 1
+	return s.tokens[i%uint64(len(s.tokens))], nil
+}
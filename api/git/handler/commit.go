@@ -1,50 +1,84 @@
 package handler
 
 import (
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/utils"
-	"encoding/json"
-	"fmt"
+	"api_git_leet_duo/api/validate"
 	"net/http"
-	"sort"
+	"time"
 )
 
 func GitCommit(w http.ResponseWriter, r *http.Request) {
-	username := r.URL.Query().Get("user")
+	q := r.URL.Query()
+	username := q.Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	startingYear := 2015
-	graphs, err := utils.GetContributionGraphs(username, startingYear)
+	window, err := utils.ParseContributionRange(q, time.Now().UTC())
+	if err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+	shape, err := utils.ParseContributionShape(q.Get("shape"))
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Error retrieving graphsContribuitions: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.InvalidParam("shape", err))
 		return
 	}
 
-	sortYears := []int{}
-	for year := range graphs {
-		sortYears = append(sortYears, year)
+	var graphs map[int]utils.Response
+	if window.IsZero() {
+		graphs, err = utils.GetContributionHistory(r.Context(), githubclient.FromContext(r.Context()), username)
+	} else {
+		graphs, err = utils.GetContributionWindow(r.Context(), githubclient.FromContext(r.Context()), username, window.From, window.To)
+	}
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
 	}
-	sort.Ints(sortYears)
 
+	days := make([]utils.ContributionDay, 0)
+	for _, day := range utils.SortedContributionDays(graphs) {
+		if window.Contains(day.Date) {
+			days = append(days, day)
+		}
+	}
 
 	// Monta a resposta JSON
 	response := make(map[string]interface{})
 	response["user"] = username
-	response["commit"] = graphs
+	if !window.IsZero() {
+		response["from"] = window.From.Format("2006-01-02")
+		response["to"] = window.To.Format("2006-01-02")
+	} else if len(days) > 0 {
+		response["from"] = days[0].Date
+		response["to"] = days[len(days)-1].Date
+	}
+	if shape == utils.ShapeRaw {
+		response["commit"] = graphs
+	} else {
+		response[shape] = utils.AggregateContributions(days, shape)
+	}
 
 	// Calcula o total de commits
 	total := 0
-	for _, year := range sortYears {
-		for _, week := range graphs[year].Data.User.ContributionsCollection.ContributionCalendar.Weeks {
-			for _, day := range week.ContributionDays {
-				total += day.ContributionCount
-			}
-		}
+	for _, day := range days {
+		total += day.ContributionCount
 	}
 	response["total"] = total
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	byYear, byType := utils.GetContributionBreakdown(graphs)
+	response["by_type"] = map[string]interface{}{
+		"total": byType,
+		"years": byYear,
+	}
+
+	cache.WriteJSON(w, r, response, cache.Computed(cache.TTL(r.Context())))
 }
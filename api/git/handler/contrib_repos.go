@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// maxContribRepos bounds ?limit= on /api/git/contrib_repos.
+const maxContribRepos = 100
+
+// GitContribRepos returns the repositories the user contributed to in
+// ?year=, the current year by default, the most contributed first.
+func GitContribRepos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	currentYear := time.Now().Year()
+	year := currentYear
+	if value := q.Get("year"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < utils.FirstContributionYear || n > currentYear {
+			apierror.Write(w, apierror.InvalidParam("year", fmt.Errorf("must be between %d and %d", utils.FirstContributionYear, currentYear)))
+			return
+		}
+		year = n
+	}
+
+	limit := 10
+	if value := q.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxContribRepos {
+			apierror.Write(w, apierror.InvalidParam("limit", fmt.Errorf("must be a number between 1 and %d", maxContribRepos)))
+			return
+		}
+		limit = n
+	}
+
+	repos, err := utils.GetContributionRepos(r.Context(), githubclient.FromContext(r.Context()), username, year)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	var totals utils.RepoContribution
+	for _, repo := range repos {
+		totals.Commits += repo.Commits
+		totals.PullRequests += repo.PullRequests
+		totals.Issues += repo.Issues
+		totals.Total += repo.Total
+	}
+
+	response := map[string]interface{}{
+		"user":  username,
+		"year":  year,
+		"count": len(repos),
+		"totals": map[string]int{
+			"commits":       totals.Commits,
+			"pull_requests": totals.PullRequests,
+			"issues":        totals.Issues,
+			"total":         totals.Total,
+		},
+		"repositories": repos[:min(limit, len(repos))],
+	}
+
+	cache.WriteJSON(w, r, response, cache.Computed(cache.TTL(r.Context())))
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/config"
+	"api_git_leet_duo/api/git/githubclient"
+)
+
+// serve runs handler on target with a client pointed at github and a fresh
+// cache.
+func serve(t *testing.T, handler http.HandlerFunc, target string, github http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	server := httptest.NewServer(github)
+	t.Cleanup(server.Close)
+	client := githubclient.New(githubclient.NewStaticTokens([]string{"token"}))
+	client.BaseURL = server.URL
+
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	ctx := config.WithConfig(r.Context(), config.Config{CacheTTL: config.DefaultCacheTTL})
+	ctx = githubclient.WithClient(ctx, client)
+	ctx = cache.WithCache(ctx, cache.NewMemory(16))
+	w := httptest.NewRecorder()
+	handler(w, r.WithContext(ctx))
+	return w
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/card"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitHeatmapSVG renders the user's contribution calendar as an SVG heatmap.
+func GitHeatmapSVG(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	from, to, err := card.ParseHeatmapRange(q, time.Now().UTC())
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("year", err))
+		return
+	}
+	cellSize, err := card.ParseCellSize(q)
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("cell_size", err))
+		return
+	}
+
+	// Only the rendered range is fetched: one or two calendar years.
+	fromDay, _ := time.Parse("2006-01-02", from)
+	toDay, _ := time.Parse("2006-01-02", to)
+	graphs, err := utils.GetContributionWindow(r.Context(), githubclient.FromContext(r.Context()), username, fromDay, toDay)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, day := range utils.SortedContributionDays(graphs) {
+		counts[day.Date] = day.ContributionCount
+	}
+
+	title := fmt.Sprintf("%s on GitHub", username)
+	if year := q.Get("year"); year != "" {
+		title = fmt.Sprintf("%s on GitHub in %s", username, year)
+	}
+	svg, err := card.RenderHeatmap(card.HeatmapData{Title: title, From: from, To: to, Counts: counts}, card.HeatmapOptions{
+		Theme:    card.ParseTheme(q),
+		Palette:  card.ParsePalette(q),
+		Locale:   card.LookupLocale(q.Get("locale")),
+		CellSize: cellSize,
+		Hidden:   card.ParseHidden(q),
+	})
+	if err != nil {
+		apierror.Write(w, apierror.New(http.StatusInternalServerError, apierror.Internal, err.Error()))
+		return
+	}
+
+	cache.Write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), cache.Computed(cache.TTL(r.Context())))
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGitHeatmapSVGFetchesOnlyTheRenderedRange(t *testing.T) {
+	var mu sync.Mutex
+	var windows []string
+	w := serve(t, GitHeatmapSVG, "/api/git/heatmap.svg?user=octocat&year=2020", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		windows = append(windows, body.Variables["from"].(string)[:10]+".."+body.Variables["to"].(string)[:10])
+		mu.Unlock()
+		w.Write([]byte(`{"data":{"user":{"createdAt":"2010-01-01T00:00:00Z","contributionsCollection":{"contributionCalendar":{"weeks":[
+			{"contributionDays":[{"date":"2020-03-01","contributionCount":4}]}]}}}}}`))
+	})
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<svg") {
+		t.Fatalf("got %d %s", w.Code, w.Body.String())
+	}
+	if len(windows) != 1 || windows[0] != "2020-01-01..2020-12-31" {
+		t.Errorf("fetched %v, want only 2020", windows)
+	}
+}
@@ -1,38 +1,47 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/service"
-	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
 )
 
 func GitLangs(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
 		return
 	}
-
-	tokens := utils.GetGitHubTokens()
-	if len(tokens) == 0 {
-		http.Error(w, "No GitHub tokens available", http.StatusInternalServerError)
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
 	// Processar linguagens
-	langPercentage, totalBytes, err := service.CalculateLanguagePercentages(username, tokens)
+	opts, err := service.ParseLanguageOptions(r.URL.Query())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Error calculating languages: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"user":        username,
-		"languages":   langPercentage,
-		"total_bytes": totalBytes,
+	key := cache.Key("github", "langs", username, opts.Params())
+	langs, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.LanguageSummary, error) {
+		langPercentage, totalBytes, err := service.CalculateLanguagePercentages(ctx, githubclient.FromContext(ctx), username, opts)
+		return service.LanguageSummary{Languages: langPercentage, TotalBytes: totalBytes}, err
 	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	cache.WriteJSON(w, r, map[string]interface{}{
+		"user":        username,
+		"languages":   langs.Languages,
+		"total_bytes": langs.TotalBytes,
+	}, result)
 }
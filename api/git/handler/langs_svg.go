@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/card"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitLangsSVG renders the user's most used languages as an SVG card.
+func GitLangsSVG(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	layout := strings.ToLower(q.Get("layout"))
+	switch layout {
+	case "":
+		layout = card.LayoutBar
+	case card.LayoutBar, card.LayoutDonut, card.LayoutList:
+	default:
+		apierror.Write(w, apierror.InvalidParam("layout", fmt.Errorf("must be %s, %s or %s", card.LayoutBar, card.LayoutDonut, card.LayoutList)))
+		return
+	}
+
+	count := card.DefaultLangsCount
+	if value := q.Get("langs_count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > card.MaxLangsCount {
+			apierror.Write(w, apierror.InvalidParam("langs_count", fmt.Errorf("must be a number between 1 and %d", card.MaxLangsCount)))
+			return
+		}
+		count = n
+	}
+
+	opts, err := service.ParseLanguageOptions(q)
+	if err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	key := cache.Key("github", "langs", username, opts.Params())
+	langs, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.LanguageSummary, error) {
+		langPercentage, totalBytes, err := service.CalculateLanguagePercentages(ctx, githubclient.FromContext(ctx), username, opts)
+		return service.LanguageSummary{Languages: langPercentage, TotalBytes: totalBytes}, err
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	stats := make([]card.LangStat, 0, len(langs.Languages))
+	for _, lang := range langs.Languages {
+		stats = append(stats, card.LangStat{Name: lang.Lang, Percentage: lang.Percentage})
+	}
+
+	locale := card.LookupLocale(q.Get("locale"))
+	svg := card.RenderLangsCard(card.TopLanguages(stats, count, locale.Other), card.LangsCardOptions{
+		Theme:  card.ParseTheme(q),
+		Locale: locale,
+		Layout: layout,
+		Hidden: card.ParseHidden(q),
+	})
+
+	cache.Write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), result)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitPinned returns the repositories and gists pinned on the user's profile.
+func GitPinned(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	key := cache.Key("github", "pinned", username, nil)
+	items, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) ([]service.PinnedItem, error) {
+		return service.FetchPinnedItems(ctx, githubclient.FromContext(ctx), username)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	cache.WriteJSON(w, r, map[string]interface{}{
+		"user":   username,
+		"pinned": items,
+		"count":  len(items),
+	}, result)
+}
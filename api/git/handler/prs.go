@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitPRs returns the user's pull request and issue statistics: counts by
+// state and merge rate over every pull request, and a page of ?limit=
+// merged pull requests with their median time to merge and the external
+// repositories that merged the most of them. ?cursor= reads the next page,
+// without the counts.
+func GitPRs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	limits := map[string]int{"limit": service.PRSampleSize, "top": 5}
+	for param, upper := range map[string]int{"limit": service.PRSampleSize, "top": 20} {
+		value := q.Get(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > upper {
+			apierror.Write(w, apierror.InvalidParam(param, fmt.Errorf("must be a number between 1 and %d", upper)))
+			return
+		}
+		limits[param] = n
+	}
+
+	var cursor *string
+	params := url.Values{"limit": {strconv.Itoa(limits["limit"])}}
+	if value := q.Get("cursor"); value != "" {
+		cursor = &value
+		params.Set("cursor", value)
+	}
+
+	key := cache.Key("github", "prs", username, params)
+	stats, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.PRStats, error) {
+		return service.FetchPRStats(ctx, githubclient.FromContext(ctx), username, limits["limit"], cursor)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	var medianHours interface{}
+	if median, ok := service.MedianTimeToMerge(stats.MergedPRs); ok {
+		medianHours = math.Round(median.Hours()*100) / 100
+	}
+
+	response := map[string]interface{}{
+		"user": username,
+		"sample": map[string]interface{}{
+			"merged_prs":            len(stats.MergedPRs),
+			"complete":              cursor == nil && stats.NextCursor == "",
+			"median_hours_to_merge": medianHours,
+		},
+		"top_external_repos": service.TopExternalRepos(stats.MergedPRs, username, limits["top"]),
+		"merged":             stats.MergedPRs,
+	}
+	if cursor == nil {
+		mergeRate := 0.0
+		if stats.PRsOpened > 0 {
+			mergeRate = math.Round(float64(stats.PRsMerged)*10000/float64(stats.PRsOpened)) / 100
+		}
+		response["pull_requests"] = map[string]interface{}{
+			"opened":     stats.PRsOpened,
+			"merged":     stats.PRsMerged,
+			"closed":     stats.PRsClosed,
+			"open":       stats.PRsOpen,
+			"merge_rate": mergeRate,
+		}
+		response["issues"] = map[string]int{
+			"opened": stats.IssuesOpened,
+			"closed": stats.IssuesClosed,
+			"open":   stats.IssuesOpened - stats.IssuesClosed,
+		}
+	}
+	if stats.NextCursor != "" {
+		response["next_cursor"] = stats.NextCursor
+	}
+
+	cache.WriteJSON(w, r, response, result)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGitPRsUnknownUser(t *testing.T) {
+	w := serve(t, GitPRs, "/api/git/prs?user=ghost", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if strings.Contains(body.Query, "search") {
+			t.Error("searched pull requests of an unknown user")
+		}
+		w.Write([]byte(`{"data":{"user":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a User"}]}`))
+	})
+
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "USER_NOT_FOUND") {
+		t.Errorf("got %d %s, want 404 USER_NOT_FOUND", w.Code, w.Body.String())
+	}
+}
+
+func TestGitPRsPagesTheSample(t *testing.T) {
+	var cursor, first interface{}
+	w := serve(t, GitPRs, "/api/git/prs?user=octocat&cursor=abc&limit=1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case strings.Contains(body.Query, "search(type: ISSUE, query: $q, first: $first"):
+			cursor, first = body.Variables["cursor"], body.Variables["first"]
+			w.Write([]byte(`{"data":{"search":{"pageInfo":{"hasNextPage":true,"endCursor":"def"},"nodes":[
+				{"number":1,"createdAt":"2025-01-01T00:00:00Z","mergedAt":"2025-01-01T10:00:00Z","repository":{"nameWithOwner":"org/repo"}}]}}}`))
+		case strings.Contains(body.Query, "issueCount"):
+			t.Error("counted pull requests again on a cursor page")
+		default:
+			w.Write([]byte(`{"data":{"user":{"id":"U_1"}}}`))
+		}
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d %s", w.Code, w.Body.String())
+	}
+	if cursor != "abc" || first != 1.0 {
+		t.Errorf("search cursor, first = %v, %v, want abc, 1", cursor, first)
+	}
+	var response struct {
+		PullRequests interface{}   `json:"pull_requests"`
+		Merged       []interface{} `json:"merged"`
+		NextCursor   string        `json:"next_cursor"`
+		Sample       struct {
+			MergedPRs   int     `json:"merged_prs"`
+			Complete    bool    `json:"complete"`
+			MedianHours float64 `json:"median_hours_to_merge"`
+		} `json:"sample"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.PullRequests != nil {
+		t.Error("cursor page returned the counts")
+	}
+	if len(response.Merged) != 1 {
+		t.Errorf("merged holds %d pull requests, want the whole page", len(response.Merged))
+	}
+	if response.NextCursor != "def" {
+		t.Errorf("next_cursor = %q, want def", response.NextCursor)
+	}
+	if response.Sample.MergedPRs != 1 || response.Sample.Complete || response.Sample.MedianHours != 10 {
+		t.Errorf("sample = %+v, want one incomplete pull request merged in 10 hours", response.Sample)
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitPunchcard returns the user's commits bucketed by weekday and hour in
+// the ?tz= timezone, or the SVG punch card with ?format=svg.
+func GitPunchcard(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	if q.Get("format") == "svg" {
+		GitPunchcardSVG(w, r)
+		return
+	}
+
+	loc, err := utils.LoadTimezone(q.Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+
+	key := cache.Key("github", "commit_dates", username, nil)
+	commits, _, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.CommitDates, error) {
+		return service.FetchCommitDates(ctx, githubclient.FromContext(ctx), username)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	punchcard := service.BuildPunchcard(commits.Dates, loc)
+
+	type weekday struct {
+		Day   string  `json:"day"`
+		Total int     `json:"total"`
+		Hours [24]int `json:"hours"`
+	}
+	type busiestHour struct {
+		Day   string `json:"day"`
+		Hour  int    `json:"hour"`
+		Count int    `json:"count"`
+	}
+
+	var (
+		days    = make([]weekday, 0, 7)
+		hours   [24]int
+		busiest busiestHour
+		total   int
+	)
+	for day, counts := range punchcard {
+		name := strings.ToLower(time.Weekday(day).String())
+		row := weekday{Day: name, Hours: counts}
+		for hour, count := range counts {
+			row.Total += count
+			hours[hour] += count
+			if count > busiest.Count {
+				busiest = busiestHour{Day: name, Hour: hour, Count: count}
+			}
+		}
+		total += row.Total
+		days = append(days, row)
+	}
+
+	response := map[string]interface{}{
+		"user":         username,
+		"timezone":     loc.String(),
+		"total":        total,
+		"repositories": commits.Repositories,
+		"days":         days,
+		"hours":        hours,
+	}
+	if busiest.Count > 0 {
+		response["busiest"] = busiest
+	}
+
+	cache.WriteJSON(w, r, response, cache.Computed(cache.TTL(r.Context())))
+}
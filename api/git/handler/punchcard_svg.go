@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/card"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitPunchcardSVG renders the user's commits by weekday and hour as an SVG
+// punch card for README embeds.
+func GitPunchcardSVG(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	loc, err := utils.LoadTimezone(q.Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+
+	key := cache.Key("github", "commit_dates", username, nil)
+	commits, _, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.CommitDates, error) {
+		return service.FetchCommitDates(ctx, githubclient.FromContext(ctx), username)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	svg := card.RenderPunchcard(service.BuildPunchcard(commits.Dates, loc), card.PunchcardOptions{
+		Theme:  card.ParseTheme(q),
+		Locale: card.LookupLocale(q.Get("locale")),
+		Hidden: card.ParseHidden(q),
+	})
+
+	cache.Write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), cache.Computed(cache.TTL(r.Context())))
+}
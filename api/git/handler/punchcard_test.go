@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGitPunchcardPublicReposInTimezone(t *testing.T) {
+	w := serve(t, GitPunchcard, "/api/git/punchcard?user=octocat&tz=Asia/Tokyo", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body.Query, "repositories(") {
+			w.Write([]byte(`{"data":{"user":{"id":"U_1"}}}`))
+			return
+		}
+		if !strings.Contains(body.Query, "privacy: PUBLIC") {
+			t.Error("commit history query reads private repositories")
+		}
+		w.Write([]byte(`{"data":{"user":{"repositories":{"nodes":[{"defaultBranchRef":{"target":{"history":{"nodes":[
+			{"committedDate":"2025-06-01T23:05:00Z"},{"committedDate":"2025-06-01T23:55:00Z"}]}}}}]}}}}`))
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Timezone string `json:"timezone"`
+		Total    int    `json:"total"`
+		Busiest  struct {
+			Day   string `json:"day"`
+			Hour  int    `json:"hour"`
+			Count int    `json:"count"`
+		} `json:"busiest"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	// 23:05 and 23:55 UTC on Sunday are both Monday 08:xx in Tokyo.
+	if response.Timezone != "Asia/Tokyo" || response.Total != 2 || response.Busiest.Day != "monday" || response.Busiest.Hour != 8 || response.Busiest.Count != 2 {
+		t.Errorf("response = %+v, want both commits on monday at 8 in Asia/Tokyo", response)
+	}
+}
@@ -1,38 +1,59 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/service"
-	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
 )
 
 func GitRepos(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	token, err := utils.GetGitHubTokenNative()
+	filter, err := service.ParseRepoFilter(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	repos, err := service.FetchAllRepos(username, token, nil)
+	key := cache.Key("github", "repos", username, nil)
+	repos, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) ([]service.RepoNode, error) {
+		return service.FetchAllRepos(ctx, githubclient.FromContext(ctx), username, nil)
+	})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Erro ao obter repositórios: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.FromUpstream("github", err))
 		return
 	}
 
+	page := service.FilterRepos(repos, filter)
 	response := map[string]interface{}{
-		"repositories": repos,
-		"count":        len(repos),
+		"repositories": page.Repos,
+		"count":        len(page.Repos),
+		"total":        page.Total,
+	}
+	if filter.Fields != nil {
+		projected, err := service.ProjectRepos(page.Repos, filter.Fields)
+		if err != nil {
+			apierror.Write(w, apierror.New(http.StatusInternalServerError, apierror.Internal, err.Error()))
+			return
+		}
+		response["repositories"] = projected
+	}
+	if page.NextCursor != "" {
+		response["next_cursor"] = page.NextCursor
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	cache.WriteJSON(w, r, response, result)
 }
@@ -1,37 +1,47 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/service"
-	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
 )
 
 func GitReposCount(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
 		return
 	}
-
-	token, err := utils.GetGitHubTokenNative()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	repos, err := service.FetchAllRepos(username, token, nil)
+	key := cache.Key("github", "repos", username, nil)
+	repos, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) ([]service.RepoNode, error) {
+		return service.FetchAllRepos(ctx, githubclient.FromContext(ctx), username, nil)
+	})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Erro ao obter repositórios: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.FromUpstream("github", err))
 		return
 	}
 
+	stars, forks := 0, 0
+	for _, repo := range repos {
+		stars += repo.StargazerCount
+		forks += repo.ForkCount
+	}
+
 	response := map[string]interface{}{
-		"count":        len(repos),
+		"count":       len(repos),
+		"total_stars": stars,
+		"total_forks": forks,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	cache.WriteJSON(w, r, response, result)
 }
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+	"net/http"
+	"time"
+)
+
+// GitStats serves statistics over the user's whole contribution history,
+// evaluated in the ?tz= timezone.
+func GitStats(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	loc, err := utils.LoadTimezone(r.URL.Query().Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+
+	graphs, err := utils.GetContributionHistory(r.Context(), githubclient.FromContext(r.Context()), username)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	response := map[string]interface{}{
+		"user":  username,
+		"stats": utils.ComputeContributionStats(graphs, loc, time.Now()),
+	}
+
+	cache.WriteJSON(w, r, response, cache.Computed(cache.TTL(r.Context())))
+}
@@ -1,40 +1,57 @@
 package handler
 
 import (
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/utils"
-	"encoding/json"
-	"fmt"
+	"api_git_leet_duo/api/validate"
 	"net/http"
-	"sort"
+	"time"
 )
 
 func GitStreak(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	if r.URL.Query().Get("format") == "svg" {
+		GitStreakSVG(w, r)
 		return
 	}
 
-	startingYear := 2015
-	graphs, err := utils.GetContributionGraphs(username, startingYear)
+	loc, err := utils.LoadTimezone(r.URL.Query().Get("tz"))
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Error retrieving graphsContribuitions: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.InvalidParam("tz", err))
 		return
 	}
 
-	sortYears := []int{}
-	for year := range graphs {
-		sortYears = append(sortYears, year)
+	graphs, err := utils.GetContributionHistory(r.Context(), githubclient.FromContext(r.Context()), username)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
 	}
-	sort.Ints(sortYears)
 
-	maxStreak, currentStreak := utils.GetContributionStreaks(graphs)
+	stats := utils.ComputeStreaks(graphs, loc, time.Now())
 
 	// Monta a resposta JSON
 	response := make(map[string]interface{})
 	response["user"] = username
-	response["streak"] = map[string]interface{}{"max_streak": maxStreak, "current_streak": currentStreak}
+	response["streak"] = map[string]interface{}{
+		"max_streak":        stats.Longest.Length,
+		"current_streak":    stats.Current.Length,
+		"longest":           stats.Longest,
+		"current":           stats.Current,
+		"contributed_today": stats.ContributedToday,
+		"today":             stats.Today,
+		"timezone":          stats.Timezone,
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	cache.WriteJSON(w, r, response, cache.Computed(cache.TTL(r.Context())))
 }
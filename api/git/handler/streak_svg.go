@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/card"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// GitStreakSVG renders the user's streak card as an SVG image for README embeds.
+func GitStreakSVG(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	loc, err := utils.LoadTimezone(q.Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+
+	graphs, err := utils.GetContributionHistory(r.Context(), githubclient.FromContext(r.Context()), username)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	stats := utils.ComputeStreaks(graphs, loc, time.Now())
+	data := card.StreakCardData{
+		Total:             utils.GetTotalContributions(graphs),
+		FirstContribution: utils.GetFirstContributionDate(graphs),
+		Current:           card.StreakStat{Length: stats.Current.Length, Start: stats.Current.Start, End: stats.Current.End},
+		Longest:           card.StreakStat{Length: stats.Longest.Length, Start: stats.Longest.Start, End: stats.Longest.End},
+	}
+
+	svg := card.RenderStreakCard(data, card.StreakCardOptions{
+		Theme:  card.ParseTheme(q),
+		Locale: card.LookupLocale(q.Get("locale")),
+		Hidden: card.ParseHidden(q),
+	})
+
+	cache.Write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), cache.Computed(cache.TTL(r.Context())))
+}
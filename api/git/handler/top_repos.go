@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/validate"
+)
+
+// topReposSorts maps ?by= to the repository sort it uses.
+var topReposSorts = map[string]string{
+	"stars":  service.SortStars,
+	"forks":  service.SortForks,
+	"recent": service.SortPushed,
+}
+
+// GitTopRepos returns the user's top repositories by stars, forks or most
+// recent push, for profiles without pinned repositories. Forks are left
+// out unless ?include_forks=true.
+func GitTopRepos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	by := strings.ToLower(q.Get("by"))
+	if by == "" {
+		by = "stars"
+	}
+	sortKey, ok := topReposSorts[by]
+	if !ok {
+		apierror.Write(w, apierror.InvalidParam("by", errors.New("must be stars, forks or recent")))
+		return
+	}
+
+	filter := service.RepoFilter{Sort: sortKey, Desc: true, ExcludeForks: true, Limit: 6}
+	if value := q.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > service.MaxRepoLimit {
+			apierror.Write(w, apierror.InvalidParam("limit", fmt.Errorf("must be a number between 1 and %d", service.MaxRepoLimit)))
+			return
+		}
+		filter.Limit = n
+	}
+	if value := q.Get("include_forks"); value != "" {
+		includeForks, err := strconv.ParseBool(value)
+		if err != nil {
+			apierror.Write(w, apierror.InvalidParam("include_forks", errors.New("must be true or false")))
+			return
+		}
+		filter.ExcludeForks = !includeForks
+	}
+
+	key := cache.Key("github", "repos", username, nil)
+	repos, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) ([]service.RepoNode, error) {
+		return service.FetchAllRepos(ctx, githubclient.FromContext(ctx), username, nil)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("github", err))
+		return
+	}
+
+	page := service.FilterRepos(repos, filter)
+	cache.WriteJSON(w, r, map[string]interface{}{
+		"user":         username,
+		"by":           by,
+		"repositories": page.Repos,
+		"count":        len(page.Repos),
+	}, result)
+}
@@ -1,30 +1,46 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
+	"net/url"
+	"strings"
 
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/query"
 	"api_git_leet_duo/api/git/service"
-	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
 )
 
 func GitUser(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Missing 'user' parameter", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.GitHubLogin(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	token, err := utils.GetGitHubTokenNative()
+	fields, err := query.ParseUserFields(r.URL.Query().Get("fields"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Write(w, apierror.InvalidParam("fields", err))
 		return
 	}
 
-	userInfo, err := service.FetchUserInfo(username, token)
+	var params url.Values
+	if len(fields) > 0 {
+		params = url.Values{"fields": {strings.Join(fields, ",")}}
+	}
+	key := cache.Key("github", "user", username, params)
+	userInfo, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (service.UserInfo, error) {
+		return service.FetchUserInfo(ctx, githubclient.FromContext(ctx), username, fields)
+	})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Erro ao obter dados do usuário: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, apierror.FromUpstream("github", err))
 		return
 	}
 
@@ -32,6 +48,5 @@ func GitUser(w http.ResponseWriter, r *http.Request) {
 		"user": userInfo,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	cache.WriteJSON(w, r, response, result)
 }
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGitUserFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		fetched []string
+		skipped []string
+	}{
+		{
+			name:    "defaults to the basic profile",
+			target:  "/api/git/user?user=octocat",
+			fetched: []string{"name", "login", "bio", "avatarUrl", "createdAt"},
+			skipped: []string{"organizations", "sponsors", "followers", "company"},
+		},
+		{
+			name:    "extended fields on request",
+			target:  "/api/git/user?user=octocat&fields=login,organizations",
+			fetched: []string{"login", "organizations(first: 100)"},
+			skipped: []string{"bio", "sponsors"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent string
+			w := serve(t, GitUser, tt.target, func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Query string `json:"query"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				sent = body.Query
+				w.Write([]byte(`{"data":{"user":{"login":"octocat"}}}`))
+			})
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %s", w.Code, w.Body.String())
+			}
+			for _, field := range tt.fetched {
+				if !strings.Contains(sent, "\t\t"+field) {
+					t.Errorf("query does not select %s:\n%s", field, sent)
+				}
+			}
+			for _, field := range tt.skipped {
+				if strings.Contains(sent, "\t\t"+field) {
+					t.Errorf("query selects %s:\n%s", field, sent)
+				}
+			}
+		})
+	}
+}
@@ -1,30 +1,52 @@
 package query
 
-import "fmt"
-
-func BuildUserQuery(username string) string {
-	return fmt.Sprintf(`
-	{
-		user(login: "%s") {
-			name
-			login
-			bio
-			avatarUrl
-			createdAt
-		}
-	}
-	`, username)
+import (
+	"fmt"
+	"strings"
+)
+
+// Queries take the login and cursors as GraphQL variables, never spliced
+// into the query text, so a crafted ?user= cannot rewrite the query.
+
+// userFieldSelections maps each field selectable on /api/git/user to its
+// GraphQL selection. Connections are aliased to the field name; only their
+// totalCount or nodes are read.
+var userFieldSelections = map[string]string{
+	"name":            "name",
+	"login":           "login",
+	"bio":             "bio",
+	"avatarUrl":       "avatarUrl",
+	"createdAt":       "createdAt",
+	"company":         "company",
+	"location":        "location",
+	"websiteUrl":      "websiteUrl",
+	"twitterUsername": "twitterUsername",
+	"isHireable":      "isHireable",
+	"status":          "status { emoji message }",
+	"followers":       "followers { totalCount }",
+	"following":       "following { totalCount }",
+	"sponsors":        "sponsors { totalCount }",
+	"organizations":   "organizations(first: 100) { nodes { login name avatarUrl } }",
+	"publicRepos":     "publicRepos: repositories(privacy: PUBLIC) { totalCount }",
+	"publicGists":     "publicGists: gists(privacy: PUBLIC) { totalCount }",
 }
 
-func BuildRepoQuery(username string, cursor *string) string {
-	after := ""
-	if cursor != nil {
-		after = fmt.Sprintf(`, after: "%s"`, *cursor)
-	}
-	return fmt.Sprintf(`
-{
-  user(login: "%s") {
-    repositories(first: 100, privacy: PUBLIC%s, orderBy: {field: UPDATED_AT, direction: DESC}) {
+// UserFields lists every field selectable on /api/git/user, in the order
+// they are queried.
+var UserFields = []string{
+	"name", "login", "bio", "avatarUrl", "createdAt",
+	"company", "location", "websiteUrl", "twitterUsername", "isHireable", "status",
+	"followers", "following", "sponsors", "organizations", "publicRepos", "publicGists",
+}
+
+// DefaultUserFields are the fields returned when ?fields= is not given; the
+// extended ones cost extra connections and are only fetched on request.
+var DefaultUserFields = []string{"name", "login", "bio", "avatarUrl", "createdAt"}
+
+const repoQuery = `
+query($login: String!, $after: String) {
+  user(login: $login) {
+    repositories(first: 100, privacy: PUBLIC, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
       pageInfo {
         hasNextPage
         endCursor
@@ -32,6 +54,29 @@ func BuildRepoQuery(username string, cursor *string) string {
       nodes {
         name
         createdAt
+        description
+        homepageUrl
+        pushedAt
+        diskUsage
+        isArchived
+        isFork
+        stargazerCount
+        forkCount
+        primaryLanguage {
+          name
+          color
+        }
+        licenseInfo {
+          name
+          spdxId
+        }
+        repositoryTopics(first: 20) {
+          nodes {
+            topic {
+              name
+            }
+          }
+        }
         defaultBranchRef {
           target {
             ... on Commit {
@@ -51,6 +96,239 @@ func BuildRepoQuery(username string, cursor *string) string {
     }
   }
 }
+`
+
+const pinnedQuery = `
+query($login: String!) {
+  user(login: $login) {
+    pinnedItems(first: 6, types: [REPOSITORY, GIST]) {
+      nodes {
+        __typename
+        ... on Repository {
+          name
+          description
+          url
+          stargazerCount
+          forkCount
+          primaryLanguage {
+            name
+            color
+          }
+          languages(first: 10, orderBy: {field: SIZE, direction: DESC}) {
+            edges {
+              size
+              node {
+                name
+                color
+              }
+            }
+          }
+        }
+        ... on Gist {
+          name
+          description
+          url
+          stargazerCount
+          files(limit: 10) {
+            name
+            language {
+              name
+              color
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const userIDQuery = `
+query($login: String!) {
+  user(login: $login) {
+    id
+  }
+}
+`
+
+const commitHistoryQuery = `
+query($login: String!, $authorId: ID!, $cursor: String) {
+  user(login: $login) {
+    repositories(first: 25, after: $cursor, ownerAffiliations: OWNER, privacy: PUBLIC, isFork: false, orderBy: {field: PUSHED_AT, direction: DESC}) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        defaultBranchRef {
+          target {
+            ... on Commit {
+              history(first: 100, author: {id: $authorId}) {
+                nodes {
+                  committedDate
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const issueCountsQuery = `
+query($prs: String!, $merged: String!, $unmerged: String!, $openPrs: String!, $issues: String!, $closedIssues: String!) {
+  prs: search(type: ISSUE, query: $prs, first: 1) {
+    issueCount
+  }
+  merged: search(type: ISSUE, query: $merged, first: 1) {
+    issueCount
+  }
+  unmerged: search(type: ISSUE, query: $unmerged, first: 1) {
+    issueCount
+  }
+  openPrs: search(type: ISSUE, query: $openPrs, first: 1) {
+    issueCount
+  }
+  issues: search(type: ISSUE, query: $issues, first: 1) {
+    issueCount
+  }
+  closedIssues: search(type: ISSUE, query: $closedIssues, first: 1) {
+    issueCount
+  }
+}
+`
+
+const mergedPullRequestsQuery = `
+query($q: String!, $first: Int!, $cursor: String) {
+  search(type: ISSUE, query: $q, first: $first, after: $cursor) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        url
+        createdAt
+        mergedAt
+        repository {
+          nameWithOwner
+        }
+      }
+    }
+  }
+}
+`
+
+// BuildUserQuery selects fields of the user, DefaultUserFields when fields
+// is empty. Field names are matched case-insensitively and only
+// ever mapped to the fixed selections above.
+func BuildUserQuery(username string, fields []string) (string, map[string]interface{}, error) {
+	if len(fields) == 0 {
+		fields = DefaultUserFields
+	}
+
+	selected := make(map[string]bool)
+	for _, field := range fields {
+		name, ok := lookupUserField(field)
+		if !ok {
+			return "", nil, fmt.Errorf("unknown field %q, expected one of %s", field, strings.Join(UserFields, ", "))
+		}
+		selected[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("query($login: String!) {\n\tuser(login: $login) {\n")
+	for _, name := range UserFields {
+		if selected[name] {
+			b.WriteString("\t\t" + userFieldSelections[name] + "\n")
+		}
+	}
+	b.WriteString("\t}\n}\n")
+	return b.String(), map[string]interface{}{"login": username}, nil
+}
+
+// ParseUserFields reads a comma-separated ?fields= value into canonical
+// field names, in UserFields order. An empty value selects the default
+// fields and returns nil.
+func ParseUserFields(value string) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, field := range strings.Split(value, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		name, ok := lookupUserField(field)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q, expected one of %s", strings.TrimSpace(field), strings.Join(UserFields, ", "))
+		}
+		selected[name] = true
+	}
+
+	var fields []string
+	for _, name := range UserFields {
+		if selected[name] {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+func lookupUserField(field string) (string, bool) {
+	for _, name := range UserFields {
+		if strings.EqualFold(name, strings.TrimSpace(field)) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func BuildRepoQuery(username string, cursor *string) (string, map[string]interface{}) {
+	vars := map[string]interface{}{"login": username}
+	if cursor != nil {
+		vars["after"] = *cursor
+	}
+	return repoQuery, vars
+}
+
+func BuildPinnedQuery(username string) (string, map[string]interface{}) {
+	return pinnedQuery, map[string]interface{}{"login": username}
+}
+
+func BuildUserIDQuery(username string) (string, map[string]interface{}) {
+	return userIDQuery, map[string]interface{}{"login": username}
+}
+
+// BuildIssueCountsQuery counts the pull requests and issues the user
+// authored, by state, with one search per count.
+func BuildIssueCountsQuery(username string) (string, map[string]interface{}) {
+	author := " author:" + username
+	return issueCountsQuery, map[string]interface{}{
+		"prs":          "is:pr" + author,
+		"merged":       "is:pr is:merged" + author,
+		"unmerged":     "is:pr is:closed is:unmerged" + author,
+		"openPrs":      "is:pr is:open" + author,
+		"issues":       "is:issue" + author,
+		"closedIssues": "is:issue is:closed" + author,
+	}
+}
+
+// BuildMergedPullRequestsQuery reads a page of first merged pull requests
+// of the user after cursor. Search cannot sort by merge date, so they come
+// most recently updated first, which a merge usually is.
+func BuildMergedPullRequestsQuery(username string, first int, cursor *string) (string, map[string]interface{}) {
+	return mergedPullRequestsQuery, map[string]interface{}{
+		"q":      "is:pr is:merged sort:updated-desc author:" + username,
+		"first":  first,
+		"cursor": cursor,
+	}
+}
 
-	`, username, after)
+// BuildCommitHistoryQuery pages through the user's own public
+// repositories, most recently pushed first, with the latest default-branch
+// commits authored by authorID in each.
+func BuildCommitHistoryQuery(username, authorID string, cursor *string) (string, map[string]interface{}) {
+	return commitHistoryQuery, map[string]interface{}{"login": username, "authorId": authorID, "cursor": cursor}
 }
@@ -1,22 +1,39 @@
 package service
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"net/http"
+	"context"
 
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/query"
 )
 
-const githubURL = "https://api.github.com/graphql"
-
-type GraphQLQuery struct {
-	Query string `json:"query"`
-}
 type RepoNode struct {
-	Name            string    `json:"name"`
-	CreatedAt       string    `json:"createdAt"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	HomepageUrl string `json:"homepageUrl"`
+	CreatedAt   string `json:"createdAt"`
+	PushedAt    string `json:"pushedAt"`
+	// DiskUsage is in kilobytes.
+	DiskUsage       int  `json:"diskUsage"`
+	IsArchived      bool `json:"isArchived"`
+	IsFork          bool `json:"isFork"`
+	StargazerCount  int  `json:"stargazerCount"`
+	ForkCount       int  `json:"forkCount"`
+	PrimaryLanguage *struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"primaryLanguage"`
+	LicenseInfo *struct {
+		Name   string `json:"name"`
+		SpdxId string `json:"spdxId"`
+	} `json:"licenseInfo"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
 	DefaultBranchRef *struct {
 		Target struct {
 			CommittedDate string `json:"committedDate"`
@@ -32,14 +49,10 @@ type RepoNode struct {
 	} `json:"languages"`
 }
 
-
-type UserInfo struct {
-	Name      string `json:"name"`
-	Login     string `json:"login"`
-	Bio       string `json:"bio"`
-	AvatarUrl string `json:"avatarUrl"`
-	CreatedAt string `json:"createdAt"`
-}
+// UserInfo holds the selected profile fields by name. Counted connections
+// such as followers are flattened to their totalCount, and organizations to
+// its list of nodes.
+type UserInfo map[string]interface{}
 
 type RepoResponse struct {
 	Data struct {
@@ -67,64 +80,47 @@ type UserResponse struct {
 	} `json:"errors"`
 }
 
-func FetchUserInfo(username, token string) (UserInfo, error) {
-	q := query.BuildUserQuery(username)
-	body, _ := json.Marshal(GraphQLQuery{Query: q})
-
-	req, err := http.NewRequest("POST", githubURL, bytes.NewBuffer(body))
+// FetchUserInfo fetches fields of the user's profile,
+// query.DefaultUserFields when fields is empty.
+func FetchUserInfo(ctx context.Context, client *githubclient.Client, username string, fields []string) (UserInfo, error) {
+	q, vars, err := query.BuildUserQuery(username, fields)
 	if err != nil {
-		return UserInfo{}, err
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return UserInfo{}, err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	var response UserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return UserInfo{}, err
-	}
-	if len(response.Errors) > 0 {
-		return UserInfo{}, errors.New(response.Errors[0].Message)
-	}
-
-	return response.Data.User, nil
-}
-
-func FetchAllRepos(username, token string, cursor *string) ([]RepoNode, error) {
-	q := query.BuildRepoQuery(username, cursor)
-	body, _ := json.Marshal(GraphQLQuery{Query: q})
-
-	req, err := http.NewRequest("POST", githubURL, bytes.NewBuffer(body))
-	if err != nil {
+	if err := client.Query(ctx, q, vars, &response); err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
+	user := response.Data.User
+	for name, value := range user {
+		connection, ok := value.(map[string]interface{})
+		if !ok || len(connection) != 1 {
+			continue
+		}
+		if count, ok := connection["totalCount"]; ok {
+			user[name] = count
+		} else if nodes, ok := connection["nodes"]; ok {
+			user[name] = nodes
+		}
 	}
-	defer resp.Body.Close()
+	return user, nil
+}
+
+func FetchAllRepos(ctx context.Context, client *githubclient.Client, username string, cursor *string) ([]RepoNode, error) {
+	q, vars := query.BuildRepoQuery(username, cursor)
 
 	var response RepoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := client.Query(ctx, q, vars, &response); err != nil {
 		return nil, err
 	}
-	if len(response.Errors) > 0 {
-		return nil, errors.New(response.Errors[0].Message)
-	}
 
 	nodes := response.Data.User.Repositories.Nodes
 
 	if response.Data.User.Repositories.PageInfo.HasNextPage {
 		nextCursor := response.Data.User.Repositories.PageInfo.EndCursor
-		nextNodes, err := FetchAllRepos(username, token, &nextCursor)
+		nextNodes, err := FetchAllRepos(ctx, client, username, &nextCursor)
 		if err != nil {
 			return nil, err
 		}
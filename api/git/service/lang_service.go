@@ -1,9 +1,15 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"math"
+	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/utils"
 )
 
@@ -12,23 +18,152 @@ type LangPercentage struct {
 	Percentage float64
 }
 
-func CalculateLanguagePercentages(username string, tokens []string) ([]LangPercentage, int, error) {
-	token, _ := utils.GetGitHubToken(tokens)
+// LanguageSummary is the cached result of CalculateLanguagePercentages.
+type LanguageSummary struct {
+	Languages  []LangPercentage
+	TotalBytes int
+}
+
+// Weight modes accepted by ?weight=.
+const (
+	WeightBytes     = "bytes"
+	WeightRepoCount = "repo-count"
+	WeightBlend     = "blend"
+)
+
+// DefaultExclude is the language left out when ?exclude= is absent:
+// notebooks store outputs alongside code and dwarf every other language.
+const DefaultExclude = "Jupyter Notebook"
+
+// LanguageOptions selects which repositories and languages are counted and
+// how each language is ranked. A language scores
+// bytes^SizeWeight * repos^CountWeight, as in github-readme-stats; the
+// zero value ranks by bytes only.
+type LanguageOptions struct {
+	// Exclude and ExcludeRepos hold lowercase names.
+	Exclude      map[string]bool
+	ExcludeRepos map[string]bool
+	IncludeForks bool
+	SizeWeight   float64
+	CountWeight  float64
+}
 
-	repos, err := utils.FetchAllRepos(username, token, nil)
+// ParseLanguageOptions reads ?exclude=, ?exclude_repos=, ?include_forks=,
+// ?weight= and, for the blend mode, ?size_weight= and ?count_weight=.
+// Without ?exclude= DefaultExclude is left out; an empty ?exclude= keeps
+// every language. ParseLanguageOptions(nil) returns the defaults.
+func ParseLanguageOptions(q url.Values) (LanguageOptions, error) {
+	exclude := DefaultExclude
+	if q.Has("exclude") {
+		exclude = q.Get("exclude")
+	}
+	opts := LanguageOptions{
+		Exclude:      parseNameList(exclude),
+		ExcludeRepos: parseNameList(q.Get("exclude_repos")),
+		SizeWeight:   1,
+	}
+
+	if value := q.Get("include_forks"); value != "" {
+		includeForks, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, fmt.Errorf("include_forks must be true or false, got %q", value)
+		}
+		opts.IncludeForks = includeForks
+	}
+
+	weight := strings.ToLower(q.Get("weight"))
+	if weight == "" && (q.Get("size_weight") != "" || q.Get("count_weight") != "") {
+		weight = WeightBlend
+	}
+	switch weight {
+	case "", WeightBytes:
+	case WeightRepoCount:
+		opts.SizeWeight, opts.CountWeight = 0, 1
+	case WeightBlend:
+		opts.SizeWeight, opts.CountWeight = 0.5, 0.5
+		for param, target := range map[string]*float64{"size_weight": &opts.SizeWeight, "count_weight": &opts.CountWeight} {
+			value := q.Get(param)
+			if value == "" {
+				continue
+			}
+			w, err := strconv.ParseFloat(value, 64)
+			if err != nil || w < 0 || w > 10 {
+				return opts, fmt.Errorf("%s must be a number between 0 and 10, got %q", param, value)
+			}
+			*target = w
+		}
+	default:
+		return opts, fmt.Errorf("weight must be %s, %s or %s, got %q", WeightBytes, WeightRepoCount, WeightBlend, weight)
+	}
+	return opts, nil
+}
+
+// Params returns the options as canonical query values, for cache keys.
+func (o LanguageOptions) Params() url.Values {
+	params := url.Values{}
+	if len(o.Exclude) > 0 {
+		params.Set("exclude", joinNames(o.Exclude))
+	}
+	if len(o.ExcludeRepos) > 0 {
+		params.Set("exclude_repos", joinNames(o.ExcludeRepos))
+	}
+	if o.IncludeForks {
+		params.Set("include_forks", "true")
+	}
+	if sw, cw := o.weights(); sw != 1 || cw != 0 {
+		params.Set("size_weight", strconv.FormatFloat(sw, 'f', -1, 64))
+		params.Set("count_weight", strconv.FormatFloat(cw, 'f', -1, 64))
+	}
+	return params
+}
+
+func (o LanguageOptions) weights() (float64, float64) {
+	if o.SizeWeight == 0 && o.CountWeight == 0 {
+		return 1, 0
+	}
+	return o.SizeWeight, o.CountWeight
+}
+
+func parseNameList(value string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func joinNames(names map[string]bool) string {
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func CalculateLanguagePercentages(ctx context.Context, client *githubclient.Client, username string, opts LanguageOptions) ([]LangPercentage, int, error) {
+	repos, err := utils.FetchAllRepos(ctx, client, username, opts.IncludeForks, nil)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	langBytes := make(map[string]int)
+	langRepos := make(map[string]int)
 
 	for _, repo := range repos {
+		if opts.ExcludeRepos[strings.ToLower(repo.Name)] {
+			continue
+		}
 		for _, edge := range repo.Languages.Edges {
 			lang := edge.Node.Name
-			if lang == "Jupyter Notebook" {
+			if opts.Exclude[strings.ToLower(lang)] {
 				continue
 			}
 			langBytes[lang] += edge.Size
+			langRepos[lang]++
 		}
 	}
 
@@ -37,14 +172,25 @@ func CalculateLanguagePercentages(username string, tokens []string) ([]LangPerce
 		totalBytes += size
 	}
 
+	// Nothing left, for example after an ?exclude= of every language, is an
+	// empty result rather than a failure.
 	if totalBytes == 0 {
-		return nil, 0, errors.New("no language data found")
+		return []LangPercentage{}, 0, nil
+	}
+
+	sizeWeight, countWeight := opts.weights()
+	scores := make(map[string]float64, len(langBytes))
+	totalScore := 0.0
+	for lang, size := range langBytes {
+		score := math.Pow(float64(size), sizeWeight) * math.Pow(float64(langRepos[lang]), countWeight)
+		scores[lang] = score
+		totalScore += score
 	}
 
 	// Cria slice de LangPercentage
 	var langPercentages []LangPercentage
-	for lang, size := range langBytes {
-		percent := (float64(size) / float64(totalBytes)) * 100
+	for lang, score := range scores {
+		percent := (score / totalScore) * 100
 		langPercentages = append(langPercentages, LangPercentage{Lang: lang, Percentage: percent})
 	}
 
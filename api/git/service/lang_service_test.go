@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"api_git_leet_duo/api/git/githubclient"
+)
+
+func TestParseLanguageOptionsExclude(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: "jupyter notebook"},
+		{query: "exclude=", want: ""},
+		{query: "exclude=HTML,%20css", want: "css,html"},
+	}
+
+	for _, tt := range tests {
+		q, err := url.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts, err := ParseLanguageOptions(q)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if got := opts.Params().Get("exclude"); got != tt.want {
+			t.Errorf("%q: exclude = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateLanguagePercentagesExcludingEverything(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"user":{"repositories":{"nodes":[
+			{"name":"api","languages":{"edges":[{"size":100,"node":{"name":"Go"}},{"size":50,"node":{"name":"HTML"}}]}}]}}}}`))
+	}))
+	defer server.Close()
+	client := githubclient.New(githubclient.NewStaticTokens([]string{"token"}))
+	client.BaseURL = server.URL
+
+	opts, err := ParseLanguageOptions(url.Values{"exclude": {"go,html"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	langs, total, err := CalculateLanguagePercentages(context.Background(), client, "octocat", opts)
+	if err != nil {
+		t.Fatalf("err = %v, want an empty result", err)
+	}
+	if langs == nil || len(langs) != 0 || total != 0 {
+		t.Errorf("got %v, %d, want an empty list", langs, total)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/query"
+)
+
+// Language is a GitHub language with its linguist color.
+type Language struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// LanguageShare is a language and the bytes of code written in it.
+type LanguageShare struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Size  int    `json:"size"`
+}
+
+// GistFile is one file of a pinned gist.
+type GistFile struct {
+	Name     string    `json:"name"`
+	Language *Language `json:"language,omitempty"`
+}
+
+// PinnedItem is a repository or gist pinned on a profile. Type is
+// "repository" or "gist"; Forks and Languages are set for repositories,
+// Files for gists.
+type PinnedItem struct {
+	Type            string          `json:"type"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	URL             string          `json:"url"`
+	Stars           int             `json:"stars"`
+	Forks           int             `json:"forks,omitempty"`
+	PrimaryLanguage *Language       `json:"primary_language,omitempty"`
+	Languages       []LanguageShare `json:"languages,omitempty"`
+	Files           []GistFile      `json:"files,omitempty"`
+}
+
+type pinnedNode struct {
+	Typename        string    `json:"__typename"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	URL             string    `json:"url"`
+	StargazerCount  int       `json:"stargazerCount"`
+	ForkCount       int       `json:"forkCount"`
+	PrimaryLanguage *Language `json:"primaryLanguage"`
+	Languages       struct {
+		Edges []struct {
+			Size int      `json:"size"`
+			Node Language `json:"node"`
+		} `json:"edges"`
+	} `json:"languages"`
+	Files []GistFile `json:"files"`
+}
+
+type PinnedResponse struct {
+	Data struct {
+		User struct {
+			PinnedItems struct {
+				Nodes []pinnedNode `json:"nodes"`
+			} `json:"pinnedItems"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// FetchPinnedItems returns the repositories and gists pinned on the user's
+// profile, in the order they are shown.
+func FetchPinnedItems(ctx context.Context, client *githubclient.Client, username string) ([]PinnedItem, error) {
+	q, vars := query.BuildPinnedQuery(username)
+
+	var response PinnedResponse
+	if err := client.Query(ctx, q, vars, &response); err != nil {
+		return nil, err
+	}
+
+	items := make([]PinnedItem, 0, len(response.Data.User.PinnedItems.Nodes))
+	for _, node := range response.Data.User.PinnedItems.Nodes {
+		item := PinnedItem{
+			Type:            strings.ToLower(node.Typename),
+			Name:            node.Name,
+			Description:     node.Description,
+			URL:             node.URL,
+			Stars:           node.StargazerCount,
+			Forks:           node.ForkCount,
+			PrimaryLanguage: node.PrimaryLanguage,
+			Files:           node.Files,
+		}
+		for _, edge := range node.Languages.Edges {
+			item.Languages = append(item.Languages, LanguageShare{Name: edge.Node.Name, Color: edge.Node.Color, Size: edge.Size})
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/query"
+)
+
+// PRSampleSize is the default and largest number of merged pull requests
+// read per request: one search page. The median and the top repositories
+// are computed over the page; clients read the next one with its cursor.
+const PRSampleSize = 100
+
+// PullRequest is a merged pull request.
+type PullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Repository string `json:"repository"`
+	CreatedAt  string `json:"createdAt"`
+	MergedAt   string `json:"mergedAt"`
+}
+
+// PRStats holds the pull request and issue activity of a user. Counts
+// cover every pull request and issue and are only read for the first page;
+// MergedPRs is one page of merged pull requests, most recently merged
+// first, and NextCursor reads the next page, empty on the last one.
+type PRStats struct {
+	PRsOpened    int
+	PRsMerged    int
+	PRsClosed    int
+	PRsOpen      int
+	IssuesOpened int
+	IssuesClosed int
+	MergedPRs    []PullRequest
+	NextCursor   string
+}
+
+// RepoPRCount is how many of the user's pull requests a repository merged.
+type RepoPRCount struct {
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+	MergedPRs  int    `json:"merged_prs"`
+}
+
+type issueCount struct {
+	IssueCount int `json:"issueCount"`
+}
+
+type issueCountsResponse struct {
+	Data struct {
+		PRs          issueCount `json:"prs"`
+		Merged       issueCount `json:"merged"`
+		Unmerged     issueCount `json:"unmerged"`
+		OpenPRs      issueCount `json:"openPrs"`
+		Issues       issueCount `json:"issues"`
+		ClosedIssues issueCount `json:"closedIssues"`
+	} `json:"data"`
+}
+
+type mergedPullRequestsResponse struct {
+	Data struct {
+		Search struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []struct {
+				Number     int    `json:"number"`
+				Title      string `json:"title"`
+				URL        string `json:"url"`
+				CreatedAt  string `json:"createdAt"`
+				MergedAt   string `json:"mergedAt"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+			} `json:"nodes"`
+		} `json:"search"`
+	} `json:"data"`
+}
+
+// FetchPRStats reads the page of first merged pull requests of the user
+// after cursor and, for the first page (nil cursor), counts their pull
+// requests and issues. The user is resolved first, since search answers an
+// unknown user with no results rather than an error.
+func FetchPRStats(ctx context.Context, client *githubclient.Client, username string, first int, cursor *string) (PRStats, error) {
+	var stats PRStats
+
+	q, vars := query.BuildUserIDQuery(username)
+	var user userIDResponse
+	if err := client.Query(ctx, q, vars, &user); err != nil {
+		return stats, err
+	}
+
+	if cursor == nil {
+		q, vars = query.BuildIssueCountsQuery(username)
+		var counts issueCountsResponse
+		if err := client.Query(ctx, q, vars, &counts); err != nil {
+			return stats, err
+		}
+		stats.PRsOpened = counts.Data.PRs.IssueCount
+		stats.PRsMerged = counts.Data.Merged.IssueCount
+		stats.PRsClosed = counts.Data.Unmerged.IssueCount
+		stats.PRsOpen = counts.Data.OpenPRs.IssueCount
+		stats.IssuesOpened = counts.Data.Issues.IssueCount
+		stats.IssuesClosed = counts.Data.ClosedIssues.IssueCount
+	}
+
+	q, vars = query.BuildMergedPullRequestsQuery(username, first, cursor)
+	var response mergedPullRequestsResponse
+	if err := client.Query(ctx, q, vars, &response); err != nil {
+		return stats, err
+	}
+
+	search := response.Data.Search
+	stats.MergedPRs = make([]PullRequest, 0, len(search.Nodes))
+	for _, node := range search.Nodes {
+		stats.MergedPRs = append(stats.MergedPRs, PullRequest{
+			Number:     node.Number,
+			Title:      node.Title,
+			URL:        node.URL,
+			Repository: node.Repository.NameWithOwner,
+			CreatedAt:  node.CreatedAt,
+			MergedAt:   node.MergedAt,
+		})
+	}
+	if search.PageInfo.HasNextPage {
+		stats.NextCursor = search.PageInfo.EndCursor
+	}
+
+	sort.SliceStable(stats.MergedPRs, func(i, j int) bool {
+		return stats.MergedPRs[i].MergedAt > stats.MergedPRs[j].MergedAt
+	})
+	return stats, nil
+}
+
+// MedianTimeToMerge returns the median time from opening to merging prs,
+// and false when none has both dates.
+func MedianTimeToMerge(prs []PullRequest) (time.Duration, bool) {
+	durations := make([]time.Duration, 0, len(prs))
+	for _, pr := range prs {
+		created, err := time.Parse(time.RFC3339, pr.CreatedAt)
+		if err != nil {
+			continue
+		}
+		merged, err := time.Parse(time.RFC3339, pr.MergedAt)
+		if err != nil {
+			continue
+		}
+		durations = append(durations, merged.Sub(created))
+	}
+	if len(durations) == 0 {
+		return 0, false
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2, true
+	}
+	return durations[mid], true
+}
+
+// TopExternalRepos returns the n repositories not owned by username that
+// merged the most of prs, ties broken by name.
+func TopExternalRepos(prs []PullRequest, username string, n int) []RepoPRCount {
+	byName := make(map[string]*RepoPRCount)
+	var repos []*RepoPRCount
+	for _, pr := range prs {
+		owner, _, _ := strings.Cut(pr.Repository, "/")
+		if strings.EqualFold(owner, username) {
+			continue
+		}
+		repo, ok := byName[pr.Repository]
+		if !ok {
+			repo = &RepoPRCount{Repository: pr.Repository, URL: "https://github.com/" + pr.Repository}
+			byName[pr.Repository] = repo
+			repos = append(repos, repo)
+		}
+		repo.MergedPRs++
+	}
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		if repos[i].MergedPRs != repos[j].MergedPRs {
+			return repos[i].MergedPRs > repos[j].MergedPRs
+		}
+		return strings.ToLower(repos[i].Repository) < strings.ToLower(repos[j].Repository)
+	})
+
+	top := make([]RepoPRCount, 0, n)
+	for _, repo := range repos[:min(n, len(repos))] {
+		top = append(top, *repo)
+	}
+	return top
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/query"
+)
+
+// PunchcardMaxRepos bounds how many repositories are walked for commits,
+// the most recently pushed first. Each contributes its latest 100 commits.
+const PunchcardMaxRepos = 100
+
+type userIDResponse struct {
+	Data struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+type commitHistoryResponse struct {
+	Data struct {
+		User struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					DefaultBranchRef *struct {
+						Target struct {
+							History struct {
+								Nodes []struct {
+									CommittedDate string `json:"committedDate"`
+								} `json:"nodes"`
+							} `json:"history"`
+						} `json:"target"`
+					} `json:"defaultBranchRef"`
+				} `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// CommitDates is the committedDate of every commit sampled for a punch card.
+type CommitDates struct {
+	Dates        []string
+	Repositories int
+}
+
+// FetchCommitDates walks the default branch of the user's own public,
+// non-fork repositories and returns the dates of the commits the user
+// authored. Private repositories are left out even when the token can
+// read them.
+func FetchCommitDates(ctx context.Context, client *githubclient.Client, username string) (CommitDates, error) {
+	var result CommitDates
+
+	q, vars := query.BuildUserIDQuery(username)
+	var user userIDResponse
+	if err := client.Query(ctx, q, vars, &user); err != nil {
+		return result, err
+	}
+
+	var cursor *string
+	for result.Repositories < PunchcardMaxRepos {
+		q, vars := query.BuildCommitHistoryQuery(username, user.Data.User.ID, cursor)
+		var response commitHistoryResponse
+		if err := client.Query(ctx, q, vars, &response); err != nil {
+			return result, err
+		}
+
+		repos := response.Data.User.Repositories
+		for _, repo := range repos.Nodes {
+			result.Repositories++
+			if repo.DefaultBranchRef == nil {
+				continue
+			}
+			for _, commit := range repo.DefaultBranchRef.Target.History.Nodes {
+				result.Dates = append(result.Dates, commit.CommittedDate)
+			}
+		}
+
+		if !repos.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &repos.PageInfo.EndCursor
+	}
+	return result, nil
+}
+
+// Punchcard counts commits by weekday, Sunday first, and hour of the day.
+type Punchcard [7][24]int
+
+// BuildPunchcard buckets dates, RFC 3339 times, by weekday and hour in loc.
+// Malformed dates are skipped.
+func BuildPunchcard(dates []string, loc *time.Location) Punchcard {
+	if loc == nil {
+		loc = time.UTC
+	}
+	var p Punchcard
+	for _, date := range dates {
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			continue
+		}
+		t = t.In(loc)
+		p[t.Weekday()][t.Hour()]++
+	}
+	return p
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildPunchcard(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2025-06-01 is a Sunday.
+	dates := []string{
+		"2025-06-01T01:30:00Z",
+		"2025-06-01T23:59:59Z",
+		"2025-06-02T14:00:00-03:00",
+		"not a date",
+	}
+
+	type cell struct {
+		day  time.Weekday
+		hour int
+	}
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want []cell
+	}{
+		{name: "UTC by default", loc: nil, want: []cell{{time.Sunday, 1}, {time.Sunday, 23}, {time.Monday, 17}}},
+		{name: "behind UTC moves early hours to the day before", loc: saoPaulo, want: []cell{{time.Saturday, 22}, {time.Sunday, 20}, {time.Monday, 14}}},
+		{name: "ahead of UTC moves late hours to the next day", loc: tokyo, want: []cell{{time.Sunday, 10}, {time.Monday, 8}, {time.Tuesday, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want Punchcard
+			for _, c := range tt.want {
+				want[c.day][c.hour]++
+			}
+			if got := BuildPunchcard(dates, tt.loc); got != want {
+				t.Errorf("punchcard = %v, want %v", got, want)
+			}
+		})
+	}
+}
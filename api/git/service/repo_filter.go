@@ -0,0 +1,306 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sort keys accepted by ?sort=.
+const (
+	SortCreated = "created"
+	SortPushed  = "pushed"
+	SortName    = "name"
+	SortSize    = "size"
+	SortStars   = "stars"
+	SortForks   = "forks"
+)
+
+// MaxRepoLimit bounds ?limit=.
+const MaxRepoLimit = 100
+
+// RepoFilter selects, orders and pages a user's repositories.
+type RepoFilter struct {
+	Sort string
+	Desc bool
+	// Lang matches any language of the repository, case-insensitively.
+	Lang string
+	// Since keeps repositories pushed at or after it.
+	Since time.Time
+	// Archived keeps only archived (true) or active (false) repositories;
+	// nil keeps both.
+	Archived *bool
+	// ExcludeForks drops forked repositories.
+	ExcludeForks bool
+	// Limit is the page size; 0 returns every match.
+	Limit  int
+	Cursor *RepoCursor
+	// Fields projects each repository to these JSON fields; nil keeps all.
+	Fields []string
+}
+
+// RepoCursor points just past the last repository of a page. It holds the
+// sort value and name of that repository rather than an offset, so pages
+// stay stable when repositories are added or removed. Sort and Desc record
+// the order the cursor was issued for.
+type RepoCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	Name  string `json:"n"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c RepoCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeRepoCursor parses a cursor returned by Encode.
+func DecodeRepoCursor(s string) (RepoCursor, error) {
+	var c RepoCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.New("malformed cursor")
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.Name == "" {
+		return c, errors.New("malformed cursor")
+	}
+	return c, nil
+}
+
+// RepoFields lists the fields accepted by ?fields=.
+func RepoFields() []string {
+	t := reflect.TypeOf(RepoNode{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// ParseRepoFilter reads ?sort=, ?order=, ?lang=, ?since=, ?archived=,
+// ?limit=, ?cursor= and ?fields=. Repositories are sorted by last push,
+// newest first, by default; names sort ascending.
+func ParseRepoFilter(q url.Values) (RepoFilter, error) {
+	f := RepoFilter{Sort: strings.ToLower(q.Get("sort")), Lang: strings.TrimSpace(q.Get("lang"))}
+
+	switch f.Sort {
+	case "":
+		f.Sort = SortPushed
+	case SortCreated, SortPushed, SortName, SortSize, SortStars, SortForks:
+	default:
+		return f, fmt.Errorf("sort must be %s, %s, %s, %s, %s or %s, got %q", SortCreated, SortPushed, SortName, SortSize, SortStars, SortForks, f.Sort)
+	}
+
+	switch order := strings.ToLower(q.Get("order")); order {
+	case "":
+		f.Desc = f.Sort != SortName
+	case "asc":
+	case "desc":
+		f.Desc = true
+	default:
+		return f, fmt.Errorf("order must be asc or desc, got %q", order)
+	}
+
+	if since := q.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", since)
+		}
+		if err != nil {
+			return f, fmt.Errorf("since must be a YYYY-MM-DD date or an RFC 3339 time, got %q", since)
+		}
+		f.Since = t
+	}
+
+	if archived := q.Get("archived"); archived != "" {
+		v, err := strconv.ParseBool(archived)
+		if err != nil {
+			return f, fmt.Errorf("archived must be true or false, got %q", archived)
+		}
+		f.Archived = &v
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > MaxRepoLimit {
+			return f, fmt.Errorf("limit must be a number between 1 and %d, got %q", MaxRepoLimit, limit)
+		}
+		f.Limit = n
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		c, err := DecodeRepoCursor(cursor)
+		if err != nil {
+			return f, err
+		}
+		if c.Sort != f.Sort {
+			return f, fmt.Errorf("cursor was issued for sort=%s", c.Sort)
+		}
+		if c.Desc != f.Desc {
+			return f, fmt.Errorf("cursor was issued for order=%s", orderName(c.Desc))
+		}
+		f.Cursor = &c
+	}
+
+	if fields := q.Get("fields"); fields != "" {
+		known := make(map[string]bool)
+		for _, name := range RepoFields() {
+			known[strings.ToLower(name)] = true
+		}
+		for _, name := range strings.Split(fields, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !known[strings.ToLower(name)] {
+				return f, fmt.Errorf("unknown field %q, expected one of %s", name, strings.Join(RepoFields(), ", "))
+			}
+			f.Fields = append(f.Fields, name)
+		}
+	}
+
+	return f, nil
+}
+
+func orderName(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// sortValue is the value repo is ordered by, formatted so that string
+// comparison matches the natural order.
+func sortValue(repo RepoNode, key string) string {
+	switch key {
+	case SortCreated:
+		return repo.CreatedAt
+	case SortName:
+		return strings.ToLower(repo.Name)
+	case SortSize:
+		return fmt.Sprintf("%012d", repo.DiskUsage)
+	case SortStars:
+		return fmt.Sprintf("%012d", repo.StargazerCount)
+	case SortForks:
+		return fmt.Sprintf("%012d", repo.ForkCount)
+	default:
+		return repo.PushedAt
+	}
+}
+
+func hasLanguage(repo RepoNode, lang string) bool {
+	for _, edge := range repo.Languages.Edges {
+		if strings.EqualFold(edge.Node.Name, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f RepoFilter) matches(repo RepoNode) bool {
+	if f.Archived != nil && repo.IsArchived != *f.Archived {
+		return false
+	}
+	if f.ExcludeForks && repo.IsFork {
+		return false
+	}
+	if f.Lang != "" && !hasLanguage(repo, f.Lang) {
+		return false
+	}
+	if !f.Since.IsZero() {
+		pushed, err := time.Parse(time.RFC3339, repo.PushedAt)
+		if err != nil || pushed.Before(f.Since) {
+			return false
+		}
+	}
+	return true
+}
+
+// RepoPage is one page of filtered repositories.
+type RepoPage struct {
+	Repos []RepoNode
+	// Total counts every repository matching the filter, across pages.
+	Total int
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
+
+// FilterRepos applies f to repos. Ties on the sort value are broken by
+// name, so the order, and therefore every cursor, is deterministic.
+func FilterRepos(repos []RepoNode, f RepoFilter) RepoPage {
+	matched := make([]RepoNode, 0, len(repos))
+	for _, repo := range repos {
+		if f.matches(repo) {
+			matched = append(matched, repo)
+		}
+	}
+
+	less := func(a, b RepoCursor) bool {
+		if a.Value != b.Value {
+			return (a.Value < b.Value) != f.Desc
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}
+	position := func(repo RepoNode) RepoCursor {
+		return RepoCursor{Sort: f.Sort, Desc: f.Desc, Value: sortValue(repo, f.Sort), Name: repo.Name}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return less(position(matched[i]), position(matched[j]))
+	})
+
+	start := 0
+	if f.Cursor != nil {
+		start = sort.Search(len(matched), func(i int) bool {
+			return less(*f.Cursor, position(matched[i]))
+		})
+	}
+	end := len(matched)
+	if f.Limit > 0 && start+f.Limit < end {
+		end = start + f.Limit
+	}
+
+	page := RepoPage{Repos: matched[start:end], Total: len(matched)}
+	if end < len(matched) && end > start {
+		page.NextCursor = position(matched[end-1]).Encode()
+	}
+	return page
+}
+
+// ProjectRepos keeps only fields of each repository. Field names match the
+// JSON names case-insensitively.
+func ProjectRepos(repos []RepoNode, fields []string) ([]map[string]interface{}, error) {
+	projected := make([]map[string]interface{}, 0, len(repos))
+	for _, repo := range repos {
+		data, err := json.Marshal(repo)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]interface{}
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		kept := make(map[string]interface{}, len(fields))
+		for key, value := range all {
+			for _, field := range fields {
+				if strings.EqualFold(key, field) {
+					kept[key] = value
+				}
+			}
+		}
+		projected = append(projected, kept)
+	}
+	return projected, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseRepoFilterRejectsCursorOfAnotherOrder(t *testing.T) {
+	cursor := RepoCursor{Sort: SortStars, Desc: true, Value: "000000000010", Name: "api"}.Encode()
+
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{query: "sort=stars&cursor=" + cursor},
+		{query: "sort=stars&order=desc&cursor=" + cursor},
+		{query: "sort=stars&order=asc&cursor=" + cursor, wantErr: "order=desc"},
+		{query: "sort=forks&order=desc&cursor=" + cursor, wantErr: "sort=stars"},
+	}
+
+	for _, tt := range tests {
+		q, err := url.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseRepoFilter(q)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: %v", tt.query, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: err = %v, want it to mention %s", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFilterReposPagesBothDirections(t *testing.T) {
+	repos := []RepoNode{{Name: "a", StargazerCount: 1}, {Name: "b", StargazerCount: 2}, {Name: "c", StargazerCount: 3}}
+
+	for _, desc := range []bool{false, true} {
+		f := RepoFilter{Sort: SortStars, Desc: desc, Limit: 2}
+		first := FilterRepos(repos, f)
+		q := url.Values{"sort": {SortStars}, "order": {orderName(desc)}, "cursor": {first.NextCursor}}
+		next, err := ParseRepoFilter(q)
+		if err != nil {
+			t.Fatalf("desc=%v: %v", desc, err)
+		}
+		next.Limit = 2
+		second := FilterRepos(repos, next)
+
+		want := "c"
+		if desc {
+			want = "a"
+		}
+		if len(second.Repos) != 1 || second.Repos[0].Name != want {
+			t.Errorf("desc=%v: second page = %+v, want %s", desc, second.Repos, want)
+		}
+	}
+}
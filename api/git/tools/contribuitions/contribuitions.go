@@ -1,35 +1,35 @@
 package contribuitions
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
-	"api_git_leet_duo/api/git/tools/auth"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/tools/graphql"
 )
 
-// buildContributionGraphQuery constructs the GraphQL query for fetching contribution data.
-func buildContributionGraphQuery(user string, year int) string {
-	start := fmt.Sprintf("%d-01-01T00:00:00Z", year)
-	end := fmt.Sprintf("%d-12-31T23:59:59Z", year)
-	return fmt.Sprintf(`query { user(login: "%s") { createdAt contributionsCollection(from: "%s", to: "%s") { contributionCalendar { weeks { contributionDays { contributionCount date } } } } } }`, user, start, end)
+// buildContributionGraphQuery constructs the GraphQL query and variables for fetching contribution data.
+func buildContributionGraphQuery(user string, year int) (string, map[string]interface{}) {
+	return `query($login: String!, $from: DateTime!, $to: DateTime!) { user(login: $login) { createdAt contributionsCollection(from: $from, to: $to) { contributionCalendar { weeks { contributionDays { contributionCount date } } } } } }`,
+		map[string]interface{}{
+			"login": user,
+			"from":  fmt.Sprintf("%d-01-01T00:00:00Z", year),
+			"to":    fmt.Sprintf("%d-12-31T23:59:59Z", year),
+		}
 }
 
 // ExecuteContributionGraphRequests executes GraphQL queries for multiple years.
-func ExecuteContributionGraphRequests(user string, years []int, tokens []string) (map[int]graphql.Response, error) {
+func ExecuteContributionGraphRequests(user string, years []int) (map[int]graphql.Response, error) {
 	responses := make(map[int]graphql.Response)
+	client := githubclient.Default()
 
 	for _, year := range years {
-		token, err := auth.GetGitHubToken(tokens)
-		if err != nil {
-			return nil, err
-		}
-
-		query := buildContributionGraphQuery(user, year)
-		response, err := graphql.ExecuteGraphQLQuery(query, token)
-		if err != nil {
+		var response graphql.Response
+		query, vars := buildContributionGraphQuery(user, year)
+		if err := client.Query(context.Background(), query, vars, &response); err != nil {
 			return nil, err
 		}
 
@@ -42,10 +42,9 @@ func ExecuteContributionGraphRequests(user string, years []int, tokens []string)
 // GetContributionGraphs retrieves contribution data for a user starting from a specific year.
 func GetContributionGraphs(user string, startingYear int) (map[int]graphql.Response, error) {
 	currentYear := time.Now().Year()
-	tokens := auth.GetGitHubTokens()
 
 	// Fetch the user's creation year
-	initialResponses, err := ExecuteContributionGraphRequests(user, []int{currentYear}, tokens)
+	initialResponses, err := ExecuteContributionGraphRequests(user, []int{currentYear})
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +53,7 @@ func GetContributionGraphs(user string, startingYear int) (map[int]graphql.Respo
 	minYear := max(startingYear, userCreatedYear)
 
 	yearsToRequest := generateYearRange(minYear, currentYear)
-	moreResponses, err := ExecuteContributionGraphRequests(user, yearsToRequest, tokens)
+	moreResponses, err := ExecuteContributionGraphRequests(user, yearsToRequest)
 	if err != nil {
 		return nil, err
 	}
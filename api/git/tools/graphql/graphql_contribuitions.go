@@ -1,14 +1,5 @@
 package graphql
 
-import (
-	"encoding/json"
-	"net/http"
-	"errors"
-	"io"
-	"bytes"
-)
-
-
 //////////// structs //////////
 
 type ContributionGraphQuery struct {
@@ -49,32 +40,3 @@ type Response struct {
 	Data   Data    `json:"data"`
 	Errors []Error `json:"errors"`
 }
-
-
-
-
-
-func ExecuteGraphQLQuery(query, token string) (Response, error) {
-	var response Response
-	body, _ := json.Marshal(ContributionGraphQuery{Query: query})
-	client := &http.Client{}
-	req, _ := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(body))
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "GitHub-Readme-Streak-Stats")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return response, err
-	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(resp.Body)
-	json.Unmarshal(data, &response)
-
-	if len(response.Errors) > 0 {
-		return response, errors.New(response.Errors[0].Message)
-	}
-
-	return response, nil
-}
\ No newline at end of file
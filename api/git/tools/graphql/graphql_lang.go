@@ -1,7 +1,6 @@
 package graphql
 
 import (
-  "fmt"
   "errors"
 )
 
@@ -14,14 +13,14 @@ func validateUser(user string) error {
 }
 
 // BuildGraphQLQueryLangFull generates a GraphQL query to retrieve the complete contribution history.
-func BuildGraphQLQueryLangFull(user string) (string, error) {
+func BuildGraphQLQueryLangFull(user string) (string, map[string]interface{}, error) {
   if err := validateUser(user); err != nil {
-    return "", err
+    return "", nil, err
   }
 
   query := `
-{
-  user(login: "%s") {
+query($login: String!) {
+  user(login: $login) {
   repositories(first: 100,privacy: PUBLIC) {
     nodes {
     name
@@ -46,18 +45,18 @@ func BuildGraphQLQueryLangFull(user string) (string, error) {
   }
 }
 `
-  return fmt.Sprintf(query, user), nil
+  return query, map[string]interface{}{"login": user}, nil
 }
 
 // BuildGraphQLQueryLite generates a GraphQL query to retrieve a lightweight contribution history.
-func BuildGraphQLQueryLite(user string) (string, error) {
+func BuildGraphQLQueryLite(user string) (string, map[string]interface{}, error) {
   if err := validateUser(user); err != nil {
-    return "", err
+    return "", nil, err
   }
 
   query := `
-{
-  user(login: "%s") {
+query($login: String!) {
+  user(login: $login) {
   repositories(first: 100,privacy: PUBLIC) {
     nodes {
     name
@@ -66,5 +65,5 @@ func BuildGraphQLQueryLite(user string) (string, error) {
   }
 }
 `
-  return fmt.Sprintf(query, user), nil
+  return query, map[string]interface{}{"login": user}, nil
 }
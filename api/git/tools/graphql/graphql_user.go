@@ -1,10 +1,5 @@
 package graphql
 
-import (
-	"fmt"
-)
-
-
 type GraphQLQuery struct {
 	Query string `json:"query"`
 }
@@ -13,11 +8,11 @@ type GraphQLQuery struct {
 
 
 
-func BuildGraphQLQueryUser(user string) string {
+func BuildGraphQLQueryUser(user string) (string, map[string]interface{}) {
 	// Esta consulta irá pegar o histórico de contribuições completo desde que o usuário entrou no GitHub
-	return fmt.Sprintf(`
-{
-user(login: "%s") {
+	return `
+query($login: String!) {
+user(login: $login) {
     name
     login
     bio
@@ -26,7 +21,7 @@ user(login: "%s") {
 	}
 }
 
-	`, user)
+	`, map[string]interface{}{"login": user}
 }
 
 
@@ -1,14 +1,13 @@
 package languages
 
 import (
-	"api_git_leet_duo/api/git/tools/auth"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
 	"api_git_leet_duo/api/git/tools/graphql"
-	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"net/http"
 	"sort"
+	"strings"
 )
 
 // Language represents a programming language.
@@ -77,34 +76,14 @@ type ResponseLite struct {
 
 // FetchUserLangsFull fetches detailed language data for a user's repositories.
 func FetchUserLangsFull(user string) (Repo, error) {
-	token, err := auth.GetGitHubTokenNative()
-	if err != nil {
-		return Repo{}, fmt.Errorf("failed to get GitHub token: %w", err)
-	}
-
-	query, err := graphql.BuildGraphQLQueryLangFull(user)
+	query, vars, err := graphql.BuildGraphQLQueryLangFull(user)
 	if err != nil {
 		return Repo{}, fmt.Errorf("failed to build GraphQL query: %w", err)
 	}
-	body, _ := json.Marshal(graphql.GraphQLQuery{Query: query})
-
-	resp, err := makeGraphQLRequest(token, body)
-	if err != nil {
-		return Repo{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return Repo{}, fmt.Errorf("request failed with status %d", resp.StatusCode)
-	}
 
 	var response ResponseLangs
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return Repo{}, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		return Repo{}, errors.New(response.Errors[0].Message)
+	if err := githubclient.Default().Query(context.Background(), query, vars, &response); err != nil {
+		return Repo{}, err
 	}
 
 	return response.Data.Repo, nil
@@ -112,71 +91,43 @@ func FetchUserLangsFull(user string) (Repo, error) {
 
 // FetchUserLite fetches lightweight repository data for a user.
 func FetchUserLite(user string) (RepoName, error) {
-	token, err := auth.GetGitHubTokenNative()
-	if err != nil {
-		return RepoName{}, fmt.Errorf("failed to get GitHub token: %w", err)
-	}
-
-	query, err := graphql.BuildGraphQLQueryLite(user)
+	query, vars, err := graphql.BuildGraphQLQueryLite(user)
 	if err != nil {
 		return RepoName{}, fmt.Errorf("failed to build GraphQL query: %w", err)
 	}
-	body, _ := json.Marshal(graphql.GraphQLQuery{Query: query})
-
-	resp, err := makeGraphQLRequest(token, body)
-	if err != nil {
-		return RepoName{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return RepoName{}, fmt.Errorf("request failed with status %d", resp.StatusCode)
-	}
 
 	var response ResponseLite
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return RepoName{}, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		return RepoName{}, errors.New(response.Errors[0].Message)
+	if err := githubclient.Default().Query(context.Background(), query, vars, &response); err != nil {
+		return RepoName{}, err
 	}
 
 	return response.Data.Repo, nil
 }
 
-// makeGraphQLRequest creates and executes a GraphQL HTTP request.
-func makeGraphQLRequest(token string, body []byte) (*http.Response, error) {
-	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-
-	return resp, nil
-}
-
 // LanguagePercentage represents the percentage of a language in a repository.
 type LanguagePercentage struct {
 	Name  string
 	Value float64
 }
 
-// CalculateLanguagePercentage calculates the percentage of each language in a user's repositories.
-func CalculateLanguagePercentage(repo Repo) ([]LanguagePercentage, float64) {
+// CalculateLanguagePercentage calculates the percentage of each language in a user's repositories,
+// skipping the languages in exclude, matched case-insensitively. A nil exclude skips
+// service.DefaultExclude.
+func CalculateLanguagePercentage(repo Repo, exclude []string) ([]LanguagePercentage, float64) {
+	if exclude == nil {
+		exclude = []string{service.DefaultExclude}
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
 	languageSizes := make(map[string]int)
 	totalSize := 0
 
 	for _, repository := range repo.Repositories.Nodes {
 		for _, edge := range repository.Languages.Edges {
-			if edge.Node.Name == "Jupyter Notebook" {
+			if excluded[strings.ToLower(edge.Node.Name)] {
 				continue
 			}
 			languageSizes[edge.Node.Name] += edge.Size
@@ -0,0 +1,41 @@
+package languages
+
+import "testing"
+
+func repoWith(sizes map[string]int) Repo {
+	var repository Repository
+	for name, size := range sizes {
+		repository.Languages.Edges = append(repository.Languages.Edges, LanguageEdge{Size: size, Node: Language{Name: name}})
+	}
+	var repo Repo
+	repo.Repositories.Nodes = []Repository{repository}
+	return repo
+}
+
+func TestCalculateLanguagePercentageExclude(t *testing.T) {
+	repo := repoWith(map[string]int{"Go": 300, "HTML": 100, "Jupyter Notebook": 600})
+
+	tests := []struct {
+		name    string
+		exclude []string
+		want    map[string]float64
+	}{
+		{name: "default", exclude: nil, want: map[string]float64{"Go": 75, "HTML": 25}},
+		{name: "none", exclude: []string{}, want: map[string]float64{"Go": 30, "HTML": 10, "Jupyter Notebook": 60}},
+		{name: "case-insensitive", exclude: []string{"jupyter notebook", " html "}, want: map[string]float64{"Go": 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			langs, _ := CalculateLanguagePercentage(repo, tt.exclude)
+			if len(langs) != len(tt.want) {
+				t.Fatalf("got %+v, want %v", langs, tt.want)
+			}
+			for _, lang := range langs {
+				if want, ok := tt.want[lang.Name]; !ok || lang.Value != want {
+					t.Errorf("%s = %v, want %v", lang.Name, lang.Value, tt.want[lang.Name])
+				}
+			}
+		})
+	}
+}
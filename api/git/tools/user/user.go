@@ -1,13 +1,9 @@
 package user
 
 import (
-	"api_git_leet_duo/api/git/tools/auth"
+	"api_git_leet_duo/api/git/githubclient"
 	"api_git_leet_duo/api/git/tools/graphql"
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
+	"context"
 )
 
 type UserInfo struct {
@@ -28,41 +24,12 @@ type ResponseInfo struct {
 }
 
 func FetchUserData(user string) (UserInfo, error) {
-	token, err := auth.GetGitHubTokenNative()
-	if err != nil {
-		return UserInfo{}, err
-	}
-
-	query := graphql.BuildGraphQLQueryUser(user)
-	body, _ := json.Marshal(graphql.GraphQLQuery{Query: query})
-
-	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(body))
-	if err != nil {
-		return UserInfo{}, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return UserInfo{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return UserInfo{}, fmt.Errorf("erro na requisição: status %d", resp.StatusCode)
-	}
+	query, vars := graphql.BuildGraphQLQueryUser(user)
 
 	var response ResponseInfo
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
+	if err := githubclient.Default().Query(context.Background(), query, vars, &response); err != nil {
 		return UserInfo{}, err
 	}
 
-	if len(response.Errors) > 0 {
-		return UserInfo{}, errors.New(response.Errors[0].Message)
-	}
-
 	return response.Data.UserInfo, nil
 }
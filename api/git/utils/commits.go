@@ -1,26 +1,18 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-	"os"
-	"errors"
-	"math/rand"
-	"encoding/json"
-	"net/http"
-	"bytes"
-	"io"
-	
-
 
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
 )
 
-type ContributionGraphQuery struct {
-	Query string `json:"query"`
-}
-
 type ContributionDay struct {
 	Date              string `json:"date"`
 	ContributionCount int    `json:"contributionCount"`
@@ -31,11 +23,18 @@ type Week struct {
 }
 
 type ContributionCalendar struct {
-	Weeks []Week `json:"weeks"`
+	TotalContributions int    `json:"totalContributions"`
+	Weeks              []Week `json:"weeks"`
 }
 
 type ContributionsCollection struct {
-	ContributionCalendar ContributionCalendar `json:"contributionCalendar"`
+	TotalCommitContributions            int                  `json:"totalCommitContributions"`
+	TotalIssueContributions             int                  `json:"totalIssueContributions"`
+	TotalPullRequestContributions       int                  `json:"totalPullRequestContributions"`
+	TotalPullRequestReviewContributions int                  `json:"totalPullRequestReviewContributions"`
+	TotalRepositoryContributions        int                  `json:"totalRepositoryContributions"`
+	RestrictedContributionsCount        int                  `json:"restrictedContributionsCount"`
+	ContributionCalendar                ContributionCalendar `json:"contributionCalendar"`
 }
 
 type User struct {
@@ -58,111 +57,196 @@ type Response struct {
 
 
 
-func executeGraphQLQuery(query, token string) (Response, error) {
-	var response Response
-	body, _ := json.Marshal(ContributionGraphQuery{Query: query})
-	client := &http.Client{}
-	req, _ := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(body))
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "GitHub-Readme-Streak-Stats")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return response, err
+const contributionGraphQuery = `
+	query($login: String!, $from: DateTime!, $to: DateTime!) {
+		user(login: $login) {
+			createdAt
+			contributionsCollection(from: $from, to: $to) {
+				totalCommitContributions
+				totalIssueContributions
+				totalPullRequestContributions
+				totalPullRequestReviewContributions
+				totalRepositoryContributions
+				contributionCalendar {
+					totalContributions
+					weeks {
+						contributionDays {
+							contributionCount
+							date
+						}
+					}
+				}
+				restrictedContributionsCount
+			}
+		}
 	}
-	defer resp.Body.Close()
+`
 
-	data, _ := io.ReadAll(resp.Body)
-	json.Unmarshal(data, &response)
+// buildContributionGraphQuery constructs the GraphQL query and variables for fetching contribution data.
+func buildContributionGraphQuery(user string, from, to time.Time) (string, map[string]interface{}) {
+	return contributionGraphQuery, contributionVars(user, from, to)
+}
 
-	if len(response.Errors) > 0 {
-		return response, errors.New(response.Errors[0].Message)
+// contributionVars are the variables of a contributionsCollection query.
+func contributionVars(user string, from, to time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"login": user,
+		"from":  from.UTC().Format(time.RFC3339),
+		"to":    to.UTC().Format(time.RFC3339),
 	}
+}
 
-	return response, nil
+// contributionWindow is a range of days within a single calendar year,
+// which keeps it under GitHub's one-year limit per contributionsCollection.
+type contributionWindow struct {
+	Year     int
+	From, To time.Time
 }
 
+// fullYear reports whether w spans the whole calendar year.
+func (w contributionWindow) fullYear() bool {
+	return w.From.YearDay() == 1 && w.To.Month() == time.December && w.To.Day() == 31
+}
 
-func getGitHubTokens() []string {
-	var tokens []string
-	if token, exists := os.LookupEnv("TOKEN"); exists {
-		tokens = append(tokens, token)
+func yearWindow(year int) contributionWindow {
+	return contributionWindow{
+		Year: year,
+		From: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
 	}
-	for i := 2; ; i++ {
-		envVar := fmt.Sprintf("TOKEN%d", i)
-		if token, exists := os.LookupEnv(envVar); exists {
-			tokens = append(tokens, token)
-		} else {
-			break
+}
+
+// splitContributionWindow splits the days from..to into one window per
+// calendar year they touch.
+func splitContributionWindow(from, to time.Time) []contributionWindow {
+	var windows []contributionWindow
+	for year := from.Year(); year <= to.Year(); year++ {
+		w := yearWindow(year)
+		if year == from.Year() {
+			w.From = from
+		}
+		if year == to.Year() {
+			w.To = to
 		}
+		windows = append(windows, w)
 	}
-	return tokens
+	return windows
 }
 
-func getGitHubToken(tokens []string) (string, error) {
-	if len(tokens) == 0 {
-		return "", errors.New("no GitHub token available")
+// fetchContributionWindow fetches one window's calendar through the cache.
+// Past years are frozen, so they are kept much longer than the current one;
+// whole years share their cache entry with every caller.
+func fetchContributionWindow(ctx context.Context, client *githubclient.Client, user string, w contributionWindow) (Response, error) {
+	ttl := cache.TTL(ctx)
+	if w.Year < time.Now().Year() {
+		ttl = cache.PastYearTTL
 	}
-	return tokens[rand.Intn(len(tokens))], nil
+	params := url.Values{"year": {strconv.Itoa(w.Year)}}
+	if !w.fullYear() {
+		params = url.Values{"from": {w.From.Format(dateLayout)}, "to": {w.To.Format(dateLayout)}}
+	}
+	key := cache.Key("github", "contributions", user, params)
+
+	response, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, ttl, func(ctx context.Context) (Response, error) {
+		var response Response
+		endOfDay := w.To.Add(24*time.Hour - time.Second)
+		query, vars := buildContributionGraphQuery(user, w.From, endOfDay)
+		err := client.Query(ctx, query, vars, &response)
+		return response, err
+	})
+	return response, err
 }
 
+// maxContributionWorkers bounds how many years are fetched at the same time,
+// so old accounts don't open a burst of connections against one token.
+const maxContributionWorkers = 4
 
-// buildContributionGraphQuery constructs the GraphQL query for fetching contribution data.
-func buildContributionGraphQuery(user string, year int) string {
-	start := fmt.Sprintf("%d-01-01T00:00:00Z", year)
-	end := fmt.Sprintf("%d-12-31T23:59:59Z", year)
-	return fmt.Sprintf(`
-		query {
-			user(login: "%s") {
-				createdAt
-				contributionsCollection(from: "%s", to: "%s") {
-					contributionCalendar {
-						totalContributions
-						weeks {
-							contributionDays {
-								contributionCount
-								date
-							}
-						}
-					}
-					restrictedContributionsCount
-				}
-			}
-		}
-	`, user, start, end)
+// ExecuteContributionGraphRequests fetches the contribution calendar of every
+// year concurrently through a bounded worker pool.
+func ExecuteContributionGraphRequests(ctx context.Context, client *githubclient.Client, user string, years []int) (map[int]Response, error) {
+	windows := make([]contributionWindow, 0, len(years))
+	for _, year := range years {
+		windows = append(windows, yearWindow(year))
+	}
+	return executeContributionWindows(ctx, client, user, windows)
 }
 
+// executeContributionWindows fetches every window concurrently, keyed by
+// year. The client rotates tokens per request, and the first error cancels
+// the requests still in flight.
+func executeContributionWindows(ctx context.Context, client *githubclient.Client, user string, windows []contributionWindow) (map[int]Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		firstErr  error
+		errOnce   sync.Once
+		responses = make(map[int]Response, len(windows))
+		jobs      = make(chan contributionWindow)
+	)
+
+	workers := min(maxContributionWorkers, len(windows))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				response, err := fetchContributionWindow(ctx, client, user, w)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("year %d: %w", w.Year, err)
+						cancel()
+					})
+					continue
+				}
 
-// ExecuteContributionGraphRequests executes GraphQL queries for multiple years.
-func ExecuteContributionGraphRequests(user string, years []int, tokens []string) (map[int]Response, error) {
-	responses := make(map[int]Response)
-
-	for _, year := range years {
-		token, err := getGitHubToken(tokens)
-		if err != nil {
-			return nil, err
-		}
+				mu.Lock()
+				responses[w.Year] = response
+				mu.Unlock()
+			}
+		}()
+	}
 
-		query := buildContributionGraphQuery(user, year)
-		response, err := executeGraphQLQuery(query, token)
-		if err != nil {
-			return nil, err
+send:
+	for _, w := range windows {
+		select {
+		case jobs <- w:
+		case <-ctx.Done():
+			break send
 		}
-
-		responses[year] = response
 	}
+	close(jobs)
+	wg.Wait()
 
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
+// GetContributionWindow retrieves the contribution data of the days from..to,
+// fetched in one chunk per calendar year and keyed by year.
+func GetContributionWindow(ctx context.Context, client *githubclient.Client, user string, from, to time.Time) (map[int]Response, error) {
+	return executeContributionWindows(ctx, client, user, splitContributionWindow(from, to))
+}
+
+// GetContributionHistory retrieves the whole contribution history of a user,
+// from the year their account was created.
+func GetContributionHistory(ctx context.Context, client *githubclient.Client, user string) (map[int]Response, error) {
+	return GetContributionGraphs(ctx, client, user, FirstContributionYear)
+}
+
 // GetContributionGraphs retrieves contribution data for a user starting from a specific year.
-func GetContributionGraphs(user string, startingYear int) (map[int]Response, error) {
+func GetContributionGraphs(ctx context.Context, client *githubclient.Client, user string, startingYear int) (map[int]Response, error) {
 	currentYear := time.Now().Year()
-	tokens := getGitHubTokens()
 
 	// Fetch the user's creation year
-	initialResponses, err := ExecuteContributionGraphRequests(user, []int{currentYear}, tokens)
+	initialResponses, err := ExecuteContributionGraphRequests(ctx, client, user, []int{currentYear})
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +255,7 @@ func GetContributionGraphs(user string, startingYear int) (map[int]Response, err
 	minYear := max(startingYear, userCreatedYear)
 
 	yearsToRequest := generateYearRange(minYear, currentYear)
-	moreResponses, err := ExecuteContributionGraphRequests(user, yearsToRequest, tokens)
+	moreResponses, err := ExecuteContributionGraphRequests(ctx, client, user, yearsToRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -204,40 +288,6 @@ func generateYearRange(start, end int) []int {
 	return years
 }
 
-// GetContributionStreaks calculates the maximum and current contribution streaks.
-func GetContributionStreaks(responses map[int]Response) (int, int) {
-	var maxStreak, currentStreak, tempStreak int
-	var lastContributionDate time.Time
-	streakActive := false
-
-	for _, response := range responses {
-		for _, week := range response.Data.User.ContributionsCollection.ContributionCalendar.Weeks {
-			for _, day := range week.ContributionDays {
-				date, _ := time.Parse("2006-01-02", day.Date)
-				if day.ContributionCount > 0 {
-					if streakActive && date.Sub(lastContributionDate).Hours() == 24 {
-						tempStreak++
-					} else {
-						tempStreak = 1
-						streakActive = true
-					}
-					lastContributionDate = date
-					maxStreak = max(maxStreak, tempStreak)
-				} else {
-					streakActive = false
-					tempStreak = 0
-				}
-			}
-		}
-	}
-
-	if streakActive {
-		currentStreak = tempStreak
-	}
-
-	return maxStreak, currentStreak
-}
-
 // GetTotalContributions calculates the total number of contributions.
 func GetTotalContributions(responses map[int]Response) int {
 	totalContributions := 0
@@ -272,6 +322,55 @@ func GetContributionsByYear(responses map[int]Response) map[int]int {
 	return contributionsByYear
 }
 
+// ContributionBreakdown counts contributions by type. Restricted counts
+// contributions to private repositories whose type is hidden; Total is the
+// calendar total.
+type ContributionBreakdown struct {
+	Commits      int `json:"commits"`
+	PullRequests int `json:"pull_requests"`
+	Issues       int `json:"issues"`
+	Reviews      int `json:"reviews"`
+	Repositories int `json:"repositories"`
+	Restricted   int `json:"restricted"`
+	Total        int `json:"total"`
+}
+
+// Add sums other into b.
+func (b *ContributionBreakdown) Add(other ContributionBreakdown) {
+	b.Commits += other.Commits
+	b.PullRequests += other.PullRequests
+	b.Issues += other.Issues
+	b.Reviews += other.Reviews
+	b.Repositories += other.Repositories
+	b.Restricted += other.Restricted
+	b.Total += other.Total
+}
+
+// Breakdown returns the contribution counts by type of the collection.
+func (c ContributionsCollection) Breakdown() ContributionBreakdown {
+	return ContributionBreakdown{
+		Commits:      c.TotalCommitContributions,
+		PullRequests: c.TotalPullRequestContributions,
+		Issues:       c.TotalIssueContributions,
+		Reviews:      c.TotalPullRequestReviewContributions,
+		Repositories: c.TotalRepositoryContributions,
+		Restricted:   c.RestrictedContributionsCount,
+		Total:        c.ContributionCalendar.TotalContributions,
+	}
+}
+
+// GetContributionBreakdown returns the contributions by type for each year
+// and summed over every year.
+func GetContributionBreakdown(responses map[int]Response) (map[int]ContributionBreakdown, ContributionBreakdown) {
+	byYear := make(map[int]ContributionBreakdown, len(responses))
+	var total ContributionBreakdown
+	for year, response := range responses {
+		breakdown := response.Data.User.ContributionsCollection.Breakdown()
+		byYear[year] = breakdown
+		total.Add(breakdown)
+	}
+	return byYear, total
+}
 
 // max returns the maximum of two integers.
 func max(a, b int) int {
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api_git_leet_duo/api/git/githubclient"
+)
+
+// fakeGraphQL answers contribution queries with one day dated on the first
+// day of the requested window, after handle has had its say. handle returns
+// false to stop the default answer.
+func fakeGraphQL(t testing.TB, handle func(w http.ResponseWriter, r *http.Request, from string) bool) *githubclient.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from, _ := body.Variables["from"].(string)
+		if handle != nil && !handle(w, r, from) {
+			return
+		}
+
+		var response Response
+		response.Data.User.CreatedAt = "2010-01-01T00:00:00Z"
+		response.Data.User.ContributionsCollection.ContributionCalendar.Weeks = []Week{{
+			ContributionDays: []ContributionDay{{Date: from[:len(dateLayout)], ContributionCount: 1}},
+		}}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	client := githubclient.New(githubclient.NewStaticTokens([]string{"test-token"}))
+	client.BaseURL = server.URL
+	return client
+}
+
+// uniqueUser keeps tests and benchmark iterations from hitting each other's
+// cache entries.
+var userSeq atomic.Int64
+
+func uniqueUser(prefix string) string {
+	return fmt.Sprintf("%s-%d-%d", prefix, time.Now().UnixNano(), userSeq.Add(1))
+}
+
+func TestExecuteContributionGraphRequestsKeysByYear(t *testing.T) {
+	client := fakeGraphQL(t, nil)
+	years := []int{2016, 2017, 2018, 2019, 2020, 2021}
+
+	responses, err := ExecuteContributionGraphRequests(context.Background(), client, uniqueUser("keyed"), years)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != len(years) {
+		t.Fatalf("got %d years, want %d", len(responses), len(years))
+	}
+	for _, year := range years {
+		days := responses[year].Data.User.ContributionsCollection.ContributionCalendar.Weeks[0].ContributionDays
+		if want := fmt.Sprintf("%d-01-01", year); days[0].Date != want {
+			t.Errorf("year %d holds the calendar of %s", year, days[0].Date)
+		}
+	}
+}
+
+func TestExecuteContributionGraphRequestsCancelsOnFirstError(t *testing.T) {
+	var cancelled, requests atomic.Int32
+	client := fakeGraphQL(t, func(w http.ResponseWriter, r *http.Request, from string) bool {
+		requests.Add(1)
+		if strings.HasPrefix(from, "2015") {
+			http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+			return false
+		}
+		select {
+		case <-r.Context().Done():
+			cancelled.Add(1)
+		case <-time.After(5 * time.Second):
+		}
+		return false
+	})
+
+	years := []int{2015, 2016, 2017, 2018, 2019, 2020, 2021, 2022, 2023, 2024}
+	start := time.Now()
+	responses, err := ExecuteContributionGraphRequests(context.Background(), client, uniqueUser("cancel"), years)
+	elapsed := time.Since(start)
+
+	if err == nil || !strings.Contains(err.Error(), "year 2015") {
+		t.Fatalf("err = %v, want the 2015 failure", err)
+	}
+	if responses != nil {
+		t.Errorf("responses = %v, want nil on error", responses)
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("took %s, in-flight requests were not cancelled", elapsed)
+	}
+	if int(requests.Load()) >= len(years) {
+		t.Errorf("%d requests were sent, queued years should be dropped after the error", requests.Load())
+	}
+
+	// The server sees the cancelled requests after the client has returned.
+	deadline := time.Now().Add(time.Second)
+	for cancelled.Load() < requests.Load()-1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, want := cancelled.Load(), requests.Load()-1; got != want {
+		t.Errorf("%d of %d in-flight requests were cancelled", got, want)
+	}
+}
+
+func TestGetContributionHistoryStartsAtAccountCreation(t *testing.T) {
+	client := fakeGraphQL(t, nil)
+
+	responses, err := GetContributionHistory(context.Background(), client, uniqueUser("history"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for year := 2010; year <= time.Now().Year(); year++ {
+		if _, ok := responses[year]; !ok {
+			t.Errorf("year %d missing", year)
+		}
+	}
+	if _, ok := responses[2009]; ok {
+		t.Error("fetched a year before the account was created")
+	}
+}
+
+func BenchmarkExecuteContributionGraphRequests(b *testing.B) {
+	const latency = 20 * time.Millisecond
+	client := fakeGraphQL(b, func(w http.ResponseWriter, r *http.Request, from string) bool {
+		time.Sleep(latency)
+		return true
+	})
+	years := generateYearRange(2010, 2026)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ExecuteContributionGraphRequests(context.Background(), client, uniqueUser("bench"), years); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shapes accepted by ?shape= on /api/git/commit.
+const (
+	ShapeRaw    = "raw"
+	ShapeDays   = "days"
+	ShapeWeeks  = "weeks"
+	ShapeMonths = "months"
+	ShapeYears  = "years"
+)
+
+// FirstContributionYear is the year GitHub launched; no calendar starts
+// earlier.
+const FirstContributionYear = 2008
+
+// ContributionRange is a span of days, both ends included. The zero value
+// stands for the user's whole history.
+type ContributionRange struct {
+	From, To time.Time
+}
+
+// IsZero reports whether r is the whole history.
+func (r ContributionRange) IsZero() bool {
+	return r.From.IsZero()
+}
+
+// Contains reports whether date, a YYYY-MM-DD day, falls within r.
+func (r ContributionRange) Contains(date string) bool {
+	if r.IsZero() {
+		return true
+	}
+	return date >= r.From.Format(dateLayout) && date <= r.To.Format(dateLayout)
+}
+
+// ParseContributionRange reads ?year= or ?from= and ?to=, as YYYY-MM-DD
+// days. to defaults to today and is capped there; from defaults to a year
+// before to. With none of them the range is zero.
+func ParseContributionRange(q url.Values, today time.Time) (ContributionRange, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	yearValue, fromValue, toValue := q.Get("year"), q.Get("from"), q.Get("to")
+
+	if yearValue != "" {
+		if fromValue != "" || toValue != "" {
+			return ContributionRange{}, errors.New("year cannot be combined with from or to")
+		}
+		year, err := strconv.Atoi(yearValue)
+		if err != nil || year < FirstContributionYear || year > today.Year() {
+			return ContributionRange{}, fmt.Errorf("year must be between %d and %d, got %q", FirstContributionYear, today.Year(), yearValue)
+		}
+		r := ContributionRange{From: yearWindow(year).From, To: yearWindow(year).To}
+		if r.To.After(today) {
+			r.To = today
+		}
+		return r, nil
+	}
+
+	if fromValue == "" && toValue == "" {
+		return ContributionRange{}, nil
+	}
+
+	r := ContributionRange{To: today}
+	if toValue != "" {
+		to, err := time.Parse(dateLayout, toValue)
+		if err != nil {
+			return r, fmt.Errorf("to must be a YYYY-MM-DD date, got %q", toValue)
+		}
+		if to.Before(today) {
+			r.To = to
+		}
+	}
+	r.From = r.To.AddDate(-1, 0, 1)
+	if fromValue != "" {
+		from, err := time.Parse(dateLayout, fromValue)
+		if err != nil {
+			return r, fmt.Errorf("from must be a YYYY-MM-DD date, got %q", fromValue)
+		}
+		r.From = from
+	}
+
+	if r.From.Year() < FirstContributionYear {
+		return r, fmt.Errorf("from must not be before %d-01-01", FirstContributionYear)
+	}
+	if r.From.After(r.To) {
+		return r, errors.New("from must not be after to")
+	}
+	return r, nil
+}
+
+// ParseContributionShape reads ?shape=, raw by default.
+func ParseContributionShape(value string) (string, error) {
+	switch shape := strings.ToLower(value); shape {
+	case "":
+		return ShapeRaw, nil
+	case ShapeRaw, ShapeDays, ShapeWeeks, ShapeMonths, ShapeYears:
+		return shape, nil
+	default:
+		return "", fmt.Errorf("shape must be %s, %s, %s, %s or %s, got %q", ShapeRaw, ShapeDays, ShapeWeeks, ShapeMonths, ShapeYears, value)
+	}
+}
+
+// ContributionCount is the number of contributions in one period of a
+// shape: the day or the Sunday starting the week as YYYY-MM-DD, the month
+// as YYYY-MM, or the year as YYYY.
+type ContributionCount struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+// AggregateContributions sums days, which must be sorted by date, into the
+// periods of shape, in order.
+func AggregateContributions(days []ContributionDay, shape string) []ContributionCount {
+	counts := make([]ContributionCount, 0)
+	for _, day := range days {
+		period := day.Date
+		switch shape {
+		case ShapeWeeks:
+			t, err := time.Parse(dateLayout, day.Date)
+			if err != nil {
+				continue
+			}
+			period = t.AddDate(0, 0, -int(t.Weekday())).Format(dateLayout)
+		case ShapeMonths:
+			period = day.Date[:min(7, len(day.Date))]
+		case ShapeYears:
+			period = day.Date[:min(4, len(day.Date))]
+		}
+
+		if n := len(counts); n > 0 && counts[n-1].Period == period {
+			counts[n-1].Count += day.ContributionCount
+			continue
+		}
+		counts = append(counts, ContributionCount{Period: period, Count: day.ContributionCount})
+	}
+	return counts
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/githubclient"
+)
+
+const contributionReposQuery = `
+	query($login: String!, $from: DateTime!, $to: DateTime!) {
+		user(login: $login) {
+			contributionsCollection(from: $from, to: $to) {
+				commitContributionsByRepository(maxRepositories: 100) {
+					...repositoryContributions
+				}
+				pullRequestContributionsByRepository(maxRepositories: 100) {
+					...repositoryContributions
+				}
+				issueContributionsByRepository(maxRepositories: 100) {
+					...repositoryContributions
+				}
+			}
+		}
+	}
+
+	fragment repositoryContributions on ContributionsByRepository {
+		repository {
+			name
+			nameWithOwner
+			url
+			owner {
+				login
+			}
+			primaryLanguage {
+				name
+				color
+			}
+		}
+		contributions {
+			totalCount
+		}
+	}
+`
+
+type repositoryContributions struct {
+	Repository struct {
+		Name          string `json:"name"`
+		NameWithOwner string `json:"nameWithOwner"`
+		URL           string `json:"url"`
+		Owner         struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		PrimaryLanguage *RepoLanguage `json:"primaryLanguage"`
+	} `json:"repository"`
+	Contributions struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"contributions"`
+}
+
+type contributionReposResponse struct {
+	Data struct {
+		User struct {
+			ContributionsCollection struct {
+				Commits      []repositoryContributions `json:"commitContributionsByRepository"`
+				PullRequests []repositoryContributions `json:"pullRequestContributionsByRepository"`
+				Issues       []repositoryContributions `json:"issueContributionsByRepository"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// RepoLanguage is the primary language of a repository.
+type RepoLanguage struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// RepoContribution counts a user's contributions to one repository.
+type RepoContribution struct {
+	Name         string        `json:"name"`
+	Owner        string        `json:"owner"`
+	URL          string        `json:"url"`
+	Language     *RepoLanguage `json:"language"`
+	Commits      int           `json:"commits"`
+	PullRequests int           `json:"pull_requests"`
+	Issues       int           `json:"issues"`
+	Total        int           `json:"total"`
+}
+
+// GetContributionRepos returns the repositories the user contributed
+// commits, pull requests or issues to during year, the most contributed
+// first. GitHub reports at most 100 repositories of each kind.
+func GetContributionRepos(ctx context.Context, client *githubclient.Client, user string, year int) ([]RepoContribution, error) {
+	w := yearWindow(year)
+	ttl := cache.TTL(ctx)
+	if year < time.Now().Year() {
+		ttl = cache.PastYearTTL
+	}
+	key := cache.Key("github", "contrib_repos", user, url.Values{"year": {strconv.Itoa(year)}})
+
+	repos, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, ttl, func(ctx context.Context) ([]RepoContribution, error) {
+		var response contributionReposResponse
+		vars := contributionVars(user, w.From, w.To.Add(24*time.Hour-time.Second))
+		if err := client.Query(ctx, contributionReposQuery, vars, &response); err != nil {
+			return nil, err
+		}
+
+		collection := response.Data.User.ContributionsCollection
+		byName := make(map[string]*RepoContribution)
+		var repos []*RepoContribution
+		add := func(entries []repositoryContributions, count func(*RepoContribution) *int) {
+			for _, entry := range entries {
+				repo, ok := byName[entry.Repository.NameWithOwner]
+				if !ok {
+					repo = &RepoContribution{
+						Name:     entry.Repository.Name,
+						Owner:    entry.Repository.Owner.Login,
+						URL:      entry.Repository.URL,
+						Language: entry.Repository.PrimaryLanguage,
+					}
+					byName[entry.Repository.NameWithOwner] = repo
+					repos = append(repos, repo)
+				}
+				*count(repo) += entry.Contributions.TotalCount
+				repo.Total += entry.Contributions.TotalCount
+			}
+		}
+		add(collection.Commits, func(r *RepoContribution) *int { return &r.Commits })
+		add(collection.PullRequests, func(r *RepoContribution) *int { return &r.PullRequests })
+		add(collection.Issues, func(r *RepoContribution) *int { return &r.Issues })
+
+		result := make([]RepoContribution, 0, len(repos))
+		for _, repo := range repos {
+			result = append(result, *repo)
+		}
+		sort.SliceStable(result, func(i, j int) bool {
+			if result[i].Total != result[j].Total {
+				return result[i].Total > result[j].Total
+			}
+			return result[i].Owner+"/"+result[i].Name < result[j].Owner+"/"+result[j].Name
+		})
+		return result, nil
+	})
+	return repos, err
+}
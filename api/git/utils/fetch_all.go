@@ -1,11 +1,9 @@
 package utils
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
+	"context"
+
+	"api_git_leet_duo/api/git/githubclient"
 )
 
 type LanguageEdge struct {
@@ -64,17 +62,10 @@ type RepoResponse struct {
 
 
 
-// Monta a query para GraphQL
-func BuildGraphQLQueryRepos(user string, cursor *string) string {
-	after := ""
-	if cursor != nil {
-		after = fmt.Sprintf(`, after: "%s"`, *cursor)
-	}
-
-	return fmt.Sprintf(`
-	{
-		user(login: "%s") {
-			repositories(first: 100, isFork: false%s) {
+const reposQuery = `
+	query($login: String!, $after: String, $isFork: Boolean) {
+		user(login: $login) {
+			repositories(first: 100, isFork: $isFork, after: $after) {
 				pageInfo {
 					hasNextPage
 					endCursor
@@ -83,12 +74,12 @@ func BuildGraphQLQueryRepos(user string, cursor *string) string {
 					name
 					createdAt
 					defaultBranchRef {
-          				target {
-            				... on Commit {
-              					committedDate
-            				}
-          				}
-        			}
+						target {
+							... on Commit {
+								committedDate
+							}
+						}
+					}
 					languages(first: 100) {
 						edges {
 							size
@@ -101,45 +92,37 @@ func BuildGraphQLQueryRepos(user string, cursor *string) string {
 			}
 		}
 	}
-	`, user, after)
-}
-
-
-// Função principal para buscar todos os repositórios
-func FetchAllRepos(user string, token string, cursor *string) ([]RepoNode, error) {
-	query := BuildGraphQLQueryRepos(user, cursor)
+`
 
-	body, _ := json.Marshal(map[string]string{"query": query})
-	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
+// Monta a query para GraphQL
+// Forks are left out unless includeForks is set.
+func BuildGraphQLQueryRepos(user string, includeForks bool, cursor *string) (string, map[string]interface{}) {
+	vars := map[string]interface{}{"login": user}
+	if !includeForks {
+		vars["isFork"] = false
 	}
+	if cursor != nil {
+		vars["after"] = *cursor
+	}
+	return reposQuery, vars
+}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// Função principal para buscar todos os repositórios
+func FetchAllRepos(ctx context.Context, client *githubclient.Client, user string, includeForks bool, cursor *string) ([]RepoNode, error) {
+	query, vars := BuildGraphQLQueryRepos(user, includeForks, cursor)
 
 	var response RepoResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
+	if err := client.Query(ctx, query, vars, &response); err != nil {
 		return nil, err
 	}
 
-	if len(response.Errors) > 0 {
-		return nil, errors.New(response.Errors[0].Message)
-	}
-
 	nodes := response.Data.User.Repositories.Nodes
 
 	// Verifica se há mais páginas
 	if response.Data.User.Repositories.PageInfo.HasNextPage {
 		nextCursor := response.Data.User.Repositories.PageInfo.EndCursor
-		nextNodes, err := FetchAllRepos(user, token, &nextCursor)
+		nextNodes, err := FetchAllRepos(ctx, client, user, includeForks, &nextCursor)
 		if err != nil {
 			return nil, err
 		}
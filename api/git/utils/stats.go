@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RollingTotals sums the contributions of the last days, today included.
+type RollingTotals struct {
+	Last7  int `json:"last_7_days"`
+	Last30 int `json:"last_30_days"`
+	Last90 int `json:"last_90_days"`
+}
+
+// YearTotal is one year's contributions and its growth over the year
+// before, in percent. Growth is nil for the first year and after an empty
+// year; Partial marks the current, unfinished year.
+type YearTotal struct {
+	Year    int      `json:"year"`
+	Total   int      `json:"total"`
+	Growth  *float64 `json:"growth"`
+	Partial bool     `json:"partial,omitempty"`
+}
+
+// ContributionStats summarises a contribution history. From and To are the
+// first and last day covered, empty for an empty history.
+type ContributionStats struct {
+	From                string  `json:"from"`
+	To                  string  `json:"to"`
+	Total               int     `json:"total"`
+	Days                int     `json:"days"`
+	ActiveDays          int     `json:"active_days"`
+	ActiveDaysPercent   float64 `json:"active_days_percent"`
+	AveragePerActiveDay float64 `json:"average_per_active_day"`
+	// The busiest periods are empty for a history without contributions.
+	BusiestDay   ContributionCount `json:"busiest_day"`
+	BusiestWeek  ContributionCount `json:"busiest_week"`
+	BusiestMonth ContributionCount `json:"busiest_month"`
+	// Weekdays runs Sunday to Saturday and Months January to December,
+	// each summed over every year.
+	Weekdays     []ContributionCount `json:"weekdays"`
+	Months       []ContributionCount `json:"months"`
+	Rolling      RollingTotals       `json:"rolling"`
+	YearOverYear []YearTotal         `json:"year_over_year"`
+	Today        string              `json:"today"`
+	Timezone     string              `json:"timezone"`
+}
+
+// ComputeContributionStats returns the statistics of the calendars in
+// responses. Days before the account was created and after today, in loc,
+// are left out.
+func ComputeContributionStats(responses map[int]Response, loc *time.Location, now time.Time) ContributionStats {
+	if loc == nil {
+		loc = time.UTC
+	}
+	today := now.In(loc).Format(dateLayout)
+
+	created := ""
+	for _, response := range responses {
+		if createdAt := response.Data.User.CreatedAt; len(createdAt) >= len(dateLayout) {
+			created = createdAt[:len(dateLayout)]
+			break
+		}
+	}
+
+	days := make([]ContributionDay, 0)
+	for _, day := range SortedContributionDays(responses) {
+		if day.Date >= created && day.Date <= today {
+			days = append(days, day)
+		}
+	}
+	return StatsFromDays(days, loc, now)
+}
+
+// StatsFromDays computes the statistics of days, which must be ordered by
+// date and end no later than today in loc.
+func StatsFromDays(days []ContributionDay, loc *time.Location, now time.Time) ContributionStats {
+	if loc == nil {
+		loc = time.UTC
+	}
+	nowIn := now.In(loc)
+	stats := ContributionStats{
+		Days:     len(days),
+		Today:    nowIn.Format(dateLayout),
+		Timezone: loc.String(),
+		Weekdays: make([]ContributionCount, 7),
+		Months:   make([]ContributionCount, 12),
+	}
+	if len(days) > 0 {
+		stats.From, stats.To = days[0].Date, days[len(days)-1].Date
+	}
+	for i := range stats.Weekdays {
+		stats.Weekdays[i].Period = strings.ToLower(time.Weekday(i).String())
+	}
+	for i := range stats.Months {
+		stats.Months[i].Period = strings.ToLower(time.Month(i + 1).String())
+	}
+
+	rollingFrom := func(n int) string {
+		return nowIn.AddDate(0, 0, 1-n).Format(dateLayout)
+	}
+	last7, last30, last90 := rollingFrom(7), rollingFrom(30), rollingFrom(90)
+
+	for _, day := range days {
+		count := day.ContributionCount
+		stats.Total += count
+		if count > 0 {
+			stats.ActiveDays++
+		}
+		if count > stats.BusiestDay.Count {
+			stats.BusiestDay = ContributionCount{Period: day.Date, Count: count}
+		}
+
+		if t, err := time.Parse(dateLayout, day.Date); err == nil {
+			stats.Weekdays[t.Weekday()].Count += count
+			stats.Months[t.Month()-1].Count += count
+		}
+
+		if day.Date >= last90 {
+			stats.Rolling.Last90 += count
+		}
+		if day.Date >= last30 {
+			stats.Rolling.Last30 += count
+		}
+		if day.Date >= last7 {
+			stats.Rolling.Last7 += count
+		}
+	}
+
+	if stats.Days > 0 {
+		stats.ActiveDaysPercent = round2(float64(stats.ActiveDays) * 100 / float64(stats.Days))
+	}
+	if stats.ActiveDays > 0 {
+		stats.AveragePerActiveDay = round2(float64(stats.Total) / float64(stats.ActiveDays))
+	}
+
+	stats.BusiestWeek = busiest(AggregateContributions(days, ShapeWeeks))
+	stats.BusiestMonth = busiest(AggregateContributions(days, ShapeMonths))
+
+	stats.YearOverYear = make([]YearTotal, 0)
+	for _, year := range AggregateContributions(days, ShapeYears) {
+		n, err := strconv.Atoi(year.Period)
+		if err != nil {
+			continue
+		}
+		total := YearTotal{Year: n, Total: year.Count, Partial: n == nowIn.Year()}
+		if len(stats.YearOverYear) > 0 {
+			if previous := stats.YearOverYear[len(stats.YearOverYear)-1]; previous.Year == n-1 && previous.Total > 0 {
+				growth := round2(float64(year.Count-previous.Total) * 100 / float64(previous.Total))
+				total.Growth = &growth
+			}
+		}
+		stats.YearOverYear = append(stats.YearOverYear, total)
+	}
+
+	return stats
+}
+
+// busiest returns the period with the most contributions, the earliest on
+// ties, or an empty count when none has any.
+func busiest(counts []ContributionCount) ContributionCount {
+	var best ContributionCount
+	for _, count := range counts {
+		if count.Count > best.Count {
+			best = count
+		}
+	}
+	return best
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComputeContributionStatsCoversWholeHistory(t *testing.T) {
+	early := responseWithDays(calendar("2012-01-01", 5, 1, 2))
+	early.Data.User.CreatedAt = "2012-01-02T08:00:00Z"
+	responses := map[int]Response{
+		2012: early,
+		2025: responseWithDays(calendar("2025-06-01", 3, 0, 4)),
+	}
+
+	stats := ComputeContributionStats(responses, time.UTC, time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC))
+
+	if stats.From != "2012-01-02" || stats.To != "2025-06-02" {
+		t.Errorf("range = %s..%s, want 2012-01-02..2025-06-02", stats.From, stats.To)
+	}
+	if stats.Total != 6 {
+		t.Errorf("total = %d, want 6: days before creation and after today are left out", stats.Total)
+	}
+	if len(stats.YearOverYear) != 2 || stats.YearOverYear[0].Year != 2012 {
+		t.Errorf("year_over_year = %+v, want 2012 and 2025", stats.YearOverYear)
+	}
+}
+
+// ones returns n counts of 1.
+func ones(n int) []int {
+	counts := make([]int, n)
+	for i := range counts {
+		counts[i] = 1
+	}
+	return counts
+}
+
+func growth(v float64) *float64 {
+	return &v
+}
+
+func TestStatsFromDays(t *testing.T) {
+	tests := []struct {
+		name  string
+		days  []ContributionDay
+		tz    string
+		now   string
+		check func(t *testing.T, stats ContributionStats)
+	}{
+		{
+			name: "empty history",
+			now:  "2025-06-30T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if stats.From != "" || stats.To != "" || stats.Total != 0 || stats.Days != 0 || stats.ActiveDays != 0 {
+					t.Errorf("stats = %+v, want an empty summary", stats)
+				}
+				if stats.ActiveDaysPercent != 0 || stats.AveragePerActiveDay != 0 {
+					t.Errorf("percent, average = %v, %v, want 0 without dividing by zero", stats.ActiveDaysPercent, stats.AveragePerActiveDay)
+				}
+				if stats.BusiestDay != (ContributionCount{}) || stats.BusiestWeek != (ContributionCount{}) || stats.BusiestMonth != (ContributionCount{}) {
+					t.Errorf("busiest = %+v %+v %+v, want empty", stats.BusiestDay, stats.BusiestWeek, stats.BusiestMonth)
+				}
+				if len(stats.Weekdays) != 7 || len(stats.Months) != 12 || stats.Weekdays[0].Period != "sunday" || stats.Months[11].Period != "december" {
+					t.Errorf("weekdays, months = %+v, %+v, want every period with a zero count", stats.Weekdays, stats.Months)
+				}
+				if stats.YearOverYear == nil || len(stats.YearOverYear) != 0 {
+					t.Errorf("year_over_year = %#v, want an empty list", stats.YearOverYear)
+				}
+			},
+		},
+		{
+			name: "active days and average",
+			days: calendar("2025-06-27", 0, 2, 0, 4),
+			now:  "2025-06-30T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if stats.Total != 6 || stats.Days != 4 || stats.ActiveDays != 2 || stats.ActiveDaysPercent != 50 || stats.AveragePerActiveDay != 3 {
+					t.Errorf("stats = %+v", stats)
+				}
+				if stats.From != "2025-06-27" || stats.To != "2025-06-30" {
+					t.Errorf("range = %s..%s", stats.From, stats.To)
+				}
+			},
+		},
+		{
+			name: "rolling totals include today",
+			days: calendar("2025-03-23", ones(100)...),
+			now:  "2025-06-30T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if want := (RollingTotals{Last7: 7, Last30: 30, Last90: 90}); stats.Rolling != want {
+					t.Errorf("rolling = %+v, want %+v", stats.Rolling, want)
+				}
+			},
+		},
+		{
+			name: "rolling totals follow the timezone's today",
+			days: calendar("2025-06-20", ones(10)...),
+			tz:   "America/Sao_Paulo",
+			now:  "2025-06-30T02:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if stats.Today != "2025-06-29" || stats.Rolling.Last7 != 7 {
+					t.Errorf("today = %s, last 7 days = %d, want 2025-06-29 and 7", stats.Today, stats.Rolling.Last7)
+				}
+			},
+		},
+		{
+			name: "weekday and month distributions",
+			// 2025-05-31 is a Saturday.
+			days: calendar("2025-05-31", 1, 2, 3, 4, 5, 6, 7, 8),
+			now:  "2025-06-07T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				weekdays := []int{2, 3, 4, 5, 6, 7, 1 + 8}
+				for i, want := range weekdays {
+					if stats.Weekdays[i].Count != want {
+						t.Errorf("%s = %d, want %d", stats.Weekdays[i].Period, stats.Weekdays[i].Count, want)
+					}
+				}
+				if stats.Months[4].Count != 1 || stats.Months[5].Count != 35 {
+					t.Errorf("may, june = %d, %d, want 1, 35", stats.Months[4].Count, stats.Months[5].Count)
+				}
+			},
+		},
+		{
+			name: "busiest periods",
+			// Weeks start on Sunday 2025-06-01 and 2025-06-08.
+			days: calendar("2025-06-01", 1, 9, 1, 0, 0, 0, 0, 4, 4, 4),
+			now:  "2025-06-10T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if want := (ContributionCount{Period: "2025-06-02", Count: 9}); stats.BusiestDay != want {
+					t.Errorf("busiest day = %+v, want %+v", stats.BusiestDay, want)
+				}
+				if want := (ContributionCount{Period: "2025-06-08", Count: 12}); stats.BusiestWeek != want {
+					t.Errorf("busiest week = %+v, want %+v", stats.BusiestWeek, want)
+				}
+				if want := (ContributionCount{Period: "2025-06", Count: 23}); stats.BusiestMonth != want {
+					t.Errorf("busiest month = %+v, want %+v", stats.BusiestMonth, want)
+				}
+			},
+		},
+		{
+			name: "ties keep the earliest period",
+			// Two weeks and two months of 5 contributions, and days of 3.
+			days: append(calendar("2025-05-25", 3, 2, 0, 0, 0, 0, 0, 3, 2), calendar("2025-06-03", 0, 0, 0, 0, 0)...),
+			now:  "2025-06-07T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if stats.BusiestDay.Period != "2025-05-25" {
+					t.Errorf("busiest day = %+v, want the earliest of the ties", stats.BusiestDay)
+				}
+				if stats.BusiestWeek.Period != "2025-05-25" || stats.BusiestWeek.Count != 5 {
+					t.Errorf("busiest week = %+v, want 2025-05-25 with 5", stats.BusiestWeek)
+				}
+				if stats.BusiestMonth.Period != "2025-05" || stats.BusiestMonth.Count != 5 {
+					t.Errorf("busiest month = %+v, want 2025-05 with 5", stats.BusiestMonth)
+				}
+			},
+		},
+		{
+			name: "single partial year has no growth",
+			days: calendar("2025-06-01", 1, 2),
+			now:  "2025-06-02T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				want := []YearTotal{{Year: 2025, Total: 3, Partial: true}}
+				if !reflect.DeepEqual(stats.YearOverYear, want) {
+					t.Errorf("year_over_year = %+v, want %+v", stats.YearOverYear, want)
+				}
+			},
+		},
+		{
+			name: "growth after an empty year, a gap and a regular year",
+			days: append(append(append(
+				calendar("2020-12-31", 0),
+				calendar("2021-12-31", 4)...),
+				calendar("2023-12-31", 8)...),
+				calendar("2024-12-31", 10)...),
+			now: "2025-01-01T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				want := []YearTotal{
+					{Year: 2020, Total: 0},
+					{Year: 2021, Total: 4},
+					{Year: 2023, Total: 8},
+					{Year: 2024, Total: 10, Growth: growth(25)},
+				}
+				if !reflect.DeepEqual(stats.YearOverYear, want) {
+					t.Errorf("year_over_year = %+v, want %+v", stats.YearOverYear, want)
+				}
+			},
+		},
+		{
+			name: "negative growth",
+			days: append(calendar("2023-06-01", 3), calendar("2024-06-01", 2)...),
+			now:  "2025-01-01T12:00:00Z",
+			check: func(t *testing.T, stats ContributionStats) {
+				if got := stats.YearOverYear[1].Growth; got == nil || *got != -33.33 {
+					t.Errorf("growth = %v, want -33.33", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz := tt.tz
+			if tz == "" {
+				tz = "UTC"
+			}
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, StatsFromDays(tt.days, mustLoad(t, tz), now))
+		})
+	}
+}
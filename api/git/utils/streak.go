@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"sort"
+	"time"
+
+	// Serverless runtimes do not always ship a zoneinfo database.
+	_ "time/tzdata"
+)
+
+const dateLayout = "2006-01-02"
+
+// Streak is a run of consecutive days with contributions.
+type Streak struct {
+	Length int    `json:"length"`
+	Start  string `json:"start,omitempty"`
+	End    string `json:"end,omitempty"`
+}
+
+// StreakStats is the result of the streak engine.
+type StreakStats struct {
+	Current          Streak `json:"current"`
+	Longest          Streak `json:"longest"`
+	Today            string `json:"today"`
+	Timezone         string `json:"timezone"`
+	ContributedToday bool   `json:"contributed_today"`
+}
+
+// SortedContributionDays flattens every calendar into a single slice ordered
+// by date, merging days that appear in more than one response.
+func SortedContributionDays(responses map[int]Response) []ContributionDay {
+	counts := make(map[string]int)
+	for _, response := range responses {
+		for _, week := range response.Data.User.ContributionsCollection.ContributionCalendar.Weeks {
+			for _, day := range week.ContributionDays {
+				counts[day.Date] = max(counts[day.Date], day.ContributionCount)
+			}
+		}
+	}
+
+	days := make([]ContributionDay, 0, len(counts))
+	for date, count := range counts {
+		days = append(days, ContributionDay{Date: date, ContributionCount: count})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// nextDate returns the calendar day after date, or "" if date is malformed.
+func nextDate(date string) string {
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, 1).Format(dateLayout)
+}
+
+// previousDate returns the calendar day before date, or "" if date is malformed.
+func previousDate(date string) string {
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, -1).Format(dateLayout)
+}
+
+// ComputeStreaks returns the current and longest contribution streaks of
+// the calendars in responses. See StreaksFromDays.
+func ComputeStreaks(responses map[int]Response, loc *time.Location, now time.Time) StreakStats {
+	return StreaksFromDays(SortedContributionDays(responses), loc, now)
+}
+
+// StreaksFromDays walks days, which must be ordered by date, and returns the
+// current and longest streaks. Missing dates count as empty days. GitHub
+// buckets days by UTC date, so both today in loc and today in UTC are grace
+// days: a day without contributions yet does not break the current streak,
+// which only breaks once the day before both is empty too.
+func StreaksFromDays(days []ContributionDay, loc *time.Location, now time.Time) StreakStats {
+	if loc == nil {
+		loc = time.UTC
+	}
+	today := now.In(loc).Format(dateLayout)
+	utcToday := now.UTC().Format(dateLayout)
+	graceFrom, latest := today, utcToday
+	if graceFrom > latest {
+		graceFrom, latest = latest, graceFrom
+	}
+	lastBeforeGrace := previousDate(graceFrom)
+
+	stats := StreakStats{Today: today, Timezone: loc.String()}
+
+	var run Streak
+	for _, day := range days {
+		if day.Date > latest {
+			break
+		}
+		if day.ContributionCount == 0 {
+			if day.Date >= graceFrom {
+				continue
+			}
+			run = Streak{}
+			continue
+		}
+		if run.Length > 0 && nextDate(run.End) != day.Date {
+			run = Streak{}
+		}
+		if run.Length == 0 {
+			run.Start = day.Date
+		}
+		run.Length++
+		run.End = day.Date
+
+		if run.Length > stats.Longest.Length {
+			stats.Longest = run
+		}
+	}
+
+	stats.ContributedToday = run.Length > 0 && run.End >= graceFrom
+	if run.Length > 0 && run.End >= lastBeforeGrace {
+		stats.Current = run
+	}
+
+	return stats
+}
+
+// GetContributionStreaks calculates the maximum and current contribution streaks in UTC.
+func GetContributionStreaks(responses map[int]Response) (int, int) {
+	stats := ComputeStreaks(responses, time.UTC, time.Now())
+	return stats.Longest.Length, stats.Current.Length
+}
+
+// GetFirstContributionDate returns the date of the first day with contributions.
+func GetFirstContributionDate(responses map[int]Response) string {
+	for _, day := range SortedContributionDays(responses) {
+		if day.ContributionCount > 0 {
+			return day.Date
+		}
+	}
+	return ""
+}
+
+// LoadTimezone resolves an IANA zone name such as "America/Sao_Paulo".
+// An empty name means UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// calendar builds one day per date from start, with the given counts.
+func calendar(start string, counts ...int) []ContributionDay {
+	t, err := time.Parse(dateLayout, start)
+	if err != nil {
+		panic(err)
+	}
+	days := make([]ContributionDay, 0, len(counts))
+	for i, count := range counts {
+		days = append(days, ContributionDay{Date: t.AddDate(0, 0, i).Format(dateLayout), ContributionCount: count})
+	}
+	return days
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestStreaksFromDays(t *testing.T) {
+	tests := []struct {
+		name             string
+		days             []ContributionDay
+		tz               string
+		now              string
+		current, longest Streak
+		contributedToday bool
+	}{
+		{
+			name:    "spans the new year",
+			days:    calendar("2025-12-29", 0, 1, 2, 3),
+			tz:      "UTC",
+			now:     "2026-01-01T12:00:00Z",
+			current: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+			longest: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+
+			contributedToday: true,
+		},
+		{
+			name:    "today without contributions yet is a grace day",
+			days:    calendar("2025-12-30", 1, 1, 1, 0),
+			tz:      "UTC",
+			now:     "2026-01-02T10:00:00Z",
+			current: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+			longest: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+		},
+		{
+			name:    "an empty yesterday breaks the streak",
+			days:    calendar("2025-12-29", 1, 1, 1, 0, 0),
+			tz:      "UTC",
+			now:     "2026-01-02T10:00:00Z",
+			longest: Streak{Length: 3, Start: "2025-12-29", End: "2025-12-31"},
+		},
+		{
+			name:    "timezone ahead of UTC keeps the UTC day in progress",
+			days:    calendar("2025-12-30", 1, 1, 1, 0),
+			tz:      "Pacific/Kiritimati",
+			now:     "2026-01-02T10:00:00Z",
+			current: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+			longest: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+		},
+		{
+			name:    "Tokyo after local midnight",
+			days:    calendar("2025-12-30", 1, 1, 1, 0),
+			tz:      "Asia/Tokyo",
+			now:     "2026-01-02T16:00:00Z",
+			current: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+			longest: Streak{Length: 3, Start: "2025-12-30", End: "2026-01-01"},
+		},
+		{
+			name:    "timezone behind UTC counts the UTC day already started",
+			days:    calendar("2025-12-30", 1, 1, 1, 2),
+			tz:      "America/Los_Angeles",
+			now:     "2026-01-02T03:00:00Z",
+			current: Streak{Length: 4, Start: "2025-12-30", End: "2026-01-02"},
+			longest: Streak{Length: 4, Start: "2025-12-30", End: "2026-01-02"},
+
+			contributedToday: true,
+		},
+		{
+			name:    "longest and current differ",
+			days:    calendar("2025-06-01", 1, 1, 1, 1, 0, 1, 1),
+			tz:      "UTC",
+			now:     "2025-06-07T08:00:00Z",
+			current: Streak{Length: 2, Start: "2025-06-06", End: "2025-06-07"},
+			longest: Streak{Length: 4, Start: "2025-06-01", End: "2025-06-04"},
+
+			contributedToday: true,
+		},
+		{
+			name: "no contributions",
+			days: calendar("2025-06-01", 0, 0, 0),
+			tz:   "UTC",
+			now:  "2025-06-03T08:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stats := StreaksFromDays(tt.days, mustLoad(t, tt.tz), now)
+			if stats.Current != tt.current {
+				t.Errorf("current = %+v, want %+v", stats.Current, tt.current)
+			}
+			if stats.Longest != tt.longest {
+				t.Errorf("longest = %+v, want %+v", stats.Longest, tt.longest)
+			}
+			if stats.ContributedToday != tt.contributedToday {
+				t.Errorf("contributed_today = %v, want %v", stats.ContributedToday, tt.contributedToday)
+			}
+		})
+	}
+}
+
+func TestSortedContributionDaysAcrossYears(t *testing.T) {
+	responses := map[int]Response{
+		2026: responseWithDays(calendar("2026-01-01", 3)),
+		2025: responseWithDays(calendar("2025-12-30", 1, 2)),
+	}
+	days := SortedContributionDays(responses)
+	want := []string{"2025-12-30", "2025-12-31", "2026-01-01"}
+	if len(days) != len(want) {
+		t.Fatalf("got %d days, want %d", len(days), len(want))
+	}
+	for i, day := range days {
+		if day.Date != want[i] {
+			t.Errorf("day %d = %s, want %s", i, day.Date, want[i])
+		}
+	}
+}
+
+func responseWithDays(days []ContributionDay) Response {
+	var r Response
+	r.Data.User.ContributionsCollection.ContributionCalendar.Weeks = []Week{{ContributionDays: days}}
+	return r
+}
@@ -0,0 +1,72 @@
+package leet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/card"
+	"api_git_leet_duo/api/leet/tools"
+	"api_git_leet_duo/api/validate"
+)
+
+// LeetHeatmapSVG renders the user's LeetCode submission calendar as an SVG heatmap.
+func LeetHeatmapSVG(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("user")
+	if username == "" {
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.LeetCodeUsername(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
+		return
+	}
+
+	from, to, err := card.ParseHeatmapRange(q, time.Now().UTC())
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("year", err))
+		return
+	}
+	cellSize, err := card.ParseCellSize(q)
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("cell_size", err))
+		return
+	}
+
+	key := cache.Key("leetcode", "user", username, nil)
+	userData, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (*tools.UserData, error) {
+		return tools.GetUserData(ctx, username)
+	})
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("leetcode", err))
+		return
+	}
+
+	counts, err := tools.SubmissionDays(userData.Data.MatchedUser.SubmissionCalendar)
+	if err != nil {
+		apierror.Write(w, apierror.FromUpstream("leetcode", err))
+		return
+	}
+
+	title := fmt.Sprintf("%s on LeetCode", username)
+	if year := q.Get("year"); year != "" {
+		title = fmt.Sprintf("%s on LeetCode in %s", username, year)
+	}
+	svg, err := card.RenderHeatmap(card.HeatmapData{Title: title, From: from, To: to, Counts: counts}, card.HeatmapOptions{
+		Theme:    card.ParseTheme(q),
+		Palette:  card.ParsePalette(q),
+		Locale:   card.LookupLocale(q.Get("locale")),
+		CellSize: cellSize,
+		Hidden:   card.ParseHidden(q),
+	})
+	if err != nil {
+		apierror.Write(w, apierror.New(http.StatusInternalServerError, apierror.Internal, err.Error()))
+		return
+	}
+
+	cache.Write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), result)
+}
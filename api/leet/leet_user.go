@@ -1,8 +1,11 @@
 package leet
 
 import (
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
 	"api_git_leet_duo/api/leet/tools"
-	"encoding/json"
+	"api_git_leet_duo/api/validate"
+	"context"
 
 
 	"net/http"
@@ -13,17 +16,23 @@ import (
 func LeetUser(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("user")
 	if username == "" {
-		http.Error(w, "Username is required", http.StatusBadRequest)
+		apierror.Write(w, apierror.MissingParam("user"))
+		return
+	}
+	if err := validate.LeetCodeUsername(username); err != nil {
+		apierror.Write(w, apierror.BadRequest(err))
 		return
 	}
 
-	userData, err := tools.GetUserData(username)
+	key := cache.Key("leetcode", "user", username, nil)
+	userData, result, err := cache.Fetch(r.Context(), cache.FromContext(r.Context()), key, cache.TTL(r.Context()), func(ctx context.Context) (*tools.UserData, error) {
+		return tools.GetUserData(ctx, username)
+	})
 	if err != nil {
-		http.Error(w, "Failed to fetch data", http.StatusInternalServerError)
+		apierror.Write(w, apierror.FromUpstream("leetcode", err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(userData)
+	cache.WriteJSON(w, r, userData, result)
 }
 
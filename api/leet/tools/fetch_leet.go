@@ -2,6 +2,7 @@ package tools
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,10 +10,15 @@ import (
 	"sort"
 	"strconv"
 	"time"
+
+	"api_git_leet_duo/api/upstream"
 )
 
 const LeetCodeAPI = "https://leetcode.com/graphql"
 
+// httpClient bounds every LeetCode call, on top of the request context.
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
 type SubmitStats struct {
 	AcSubmissionNum []struct {
 		Difficulty  string `json:"difficulty"`
@@ -62,13 +68,13 @@ type UserData struct {
 	} `json:"data"`
 }
 
-func GetUserData(username string) (*UserData, error) {
-	query := fmt.Sprintf(`{
+func GetUserData(ctx context.Context, username string) (*UserData, error) {
+	query := `query($username: String!) {
 		allQuestionsCount {
 			difficulty
 			count
 		}
-		matchedUser(username: "%s") {
+		matchedUser(username: $username) {
 			username
 			firstName
 			lastName
@@ -94,29 +100,43 @@ func GetUserData(username string) (*UserData, error) {
 				}
 			}
 		}
-		recentSubmissionList(username: "%s") {
+		recentSubmissionList(username: $username) {
 			title
 			titleSlug
 			timestamp
 			statusDisplay
 			lang
 		}
-	}`, username, username)
+	}`
 
 	reqBody := map[string]interface{}{
-		"query": query,
+		"query":     query,
+		"variables": map[string]interface{}{"username": username},
 	}
 	reqBodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Post(LeetCodeAPI, "application/json", bytes.NewBuffer(reqBodyBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, LeetCodeAPI, bytes.NewBuffer(reqBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("leetcode: %w", upstream.ErrRateLimited)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("leetcode: unexpected status %d", resp.StatusCode)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -126,6 +146,10 @@ func GetUserData(username string) (*UserData, error) {
 	if err := json.Unmarshal(body, &data); err != nil {
 		return nil, err
 	}
+	// LeetCode answers 200 with a null matchedUser for unknown usernames.
+	if data.Data.MatchedUser.Username == "" {
+		return nil, fmt.Errorf("leetcode user %q: %w", username, upstream.ErrUserNotFound)
+	}
 
 	// 📌 Processa a calendar string para calcular streaks
 	streakStats := calculateStreaks(data.Data.MatchedUser.SubmissionCalendar)
@@ -202,3 +226,25 @@ func calculateStreaks(calendar string) StreakStats {
 
 	return stats
 }
+
+// SubmissionDays parses a submissionCalendar, keyed by the unix timestamp of
+// each UTC day, into submission counts keyed by "2006-01-02" date.
+func SubmissionDays(calendar string) (map[string]int, error) {
+	days := make(map[string]int)
+	if calendar == "" {
+		return days, nil
+	}
+
+	var submissionMap map[string]int
+	if err := json.Unmarshal([]byte(calendar), &submissionMap); err != nil {
+		return nil, err
+	}
+	for tsStr, count := range submissionMap {
+		tsInt, err := strconv.ParseInt(tsStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		days[time.Unix(tsInt, 0).UTC().Format("2006-01-02")] += count
+	}
+	return days, nil
+}
@@ -0,0 +1,260 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	duotools "api_git_leet_duo/api/duo/tools"
+	"api_git_leet_duo/api/git/utils"
+	leettools "api_git_leet_duo/api/leet/tools"
+	"api_git_leet_duo/api/validate"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultActivityDays is the window returned without ?from=.
+	defaultActivityDays = 365
+	// maxActivityDays bounds the ?from=/?to= window.
+	maxActivityDays = 10 * 366
+)
+
+// ActivityDay is one day of the merged calendar. Duolingo only exposes the
+// current streak, so its count is 1 on each streak day. Score is the number
+// of platforms with activity that day.
+type ActivityDay struct {
+	Date     string `json:"date"`
+	GitHub   int    `json:"github"`
+	LeetCode int    `json:"leetcode"`
+	Duolingo int    `json:"duolingo"`
+	Total    int    `json:"total"`
+	Score    int    `json:"score"`
+}
+
+// activityProviders maps each provider to the function returning its
+// activity counts keyed by date.
+var activityProviders = map[string]func(ctx context.Context, user string, now time.Time) (map[string]int, error){
+	"github": func(ctx context.Context, user string, _ time.Time) (map[string]int, error) {
+		graphs, err := fetchContributions(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		days := make(map[string]int)
+		for _, day := range utils.SortedContributionDays(graphs) {
+			days[day.Date] = day.ContributionCount
+		}
+		return days, nil
+	},
+	"leetcode": func(ctx context.Context, user string, _ time.Time) (map[string]int, error) {
+		userData, err := fetchLeetCode(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return leettools.SubmissionDays(userData.Data.MatchedUser.SubmissionCalendar)
+	},
+	"duolingo": func(ctx context.Context, user string, now time.Time) (map[string]int, error) {
+		userData, err := fetchDuolingo(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return duolingoDays(userData, now), nil
+	},
+}
+
+// duolingoDays marks every day of the user's current Duolingo streak. When
+// the streak has no dates, it is assumed to end today.
+func duolingoDays(u duotools.User, now time.Time) map[string]int {
+	days := make(map[string]int)
+	current := u.StreakData.CurrentStreak
+
+	length := current.Length
+	if length == 0 {
+		length = u.Streak
+	}
+	if length == 0 {
+		return days
+	}
+
+	end, err := time.Parse(dateLayout, current.EndDate)
+	if err != nil {
+		end, _ = time.Parse(dateLayout, now.Format(dateLayout))
+	}
+	start, err := time.Parse(dateLayout, current.StartDate)
+	if err != nil {
+		start = end.AddDate(0, 0, 1-length)
+	}
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days[d.Format(dateLayout)] = 1
+	}
+	return days
+}
+
+// parseWindow reads ?from= and ?to=, defaulting to the last
+// defaultActivityDays days ending today.
+func parseWindow(from, to, today string) (string, string, *apierror.Error) {
+	if to == "" {
+		to = today
+	}
+	end, err := time.Parse(dateLayout, to)
+	if err != nil {
+		return "", "", apierror.InvalidParam("to", errors.New("expected YYYY-MM-DD"))
+	}
+	if from == "" {
+		from = end.AddDate(0, 0, 1-defaultActivityDays).Format(dateLayout)
+	}
+	start, err := time.Parse(dateLayout, from)
+	if err != nil {
+		return "", "", apierror.InvalidParam("from", errors.New("expected YYYY-MM-DD"))
+	}
+	if start.After(end) {
+		return "", "", apierror.InvalidParam("from", errors.New("must not be after 'to'"))
+	}
+	if end.Sub(start) > maxActivityDays*24*time.Hour {
+		return "", "", apierror.InvalidParam("from", fmt.Errorf("window is limited to %d days", maxActivityDays))
+	}
+	return from, to, nil
+}
+
+// Activity merges GitHub contributions, LeetCode submissions and the
+// Duolingo streak into a single per-day series, with a streak counting any
+// day active on at least one platform. A platform that fails is reported
+// under "errors" and left out of the series.
+func Activity(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	users := make(map[string]string)
+	validators := map[string]func(string) error{
+		"github":   validate.GitHubLogin,
+		"leetcode": validate.LeetCodeUsername,
+		"duolingo": validate.DuolingoUsername,
+	}
+	for provider, check := range validators {
+		user := q.Get(provider)
+		if user == "" {
+			continue
+		}
+		if err := check(user); err != nil {
+			apierror.Write(w, apierror.BadRequest(err))
+			return
+		}
+		users[provider] = user
+	}
+	if len(users) == 0 {
+		apierror.Write(w, apierror.New(http.StatusBadRequest, apierror.BadParam, "at least one of 'github', 'leetcode' or 'duolingo' is required"))
+		return
+	}
+
+	loc, err := utils.LoadTimezone(q.Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+	now := time.Now().In(loc)
+
+	from, to, apiErr := parseWindow(q.Get("from"), q.Get("to"), now.Format(dateLayout))
+	if apiErr != nil {
+		apierror.Write(w, apiErr)
+		return
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		counts = make(map[string]map[string]int)
+		errs   = make(map[string]*apierror.Error)
+	)
+	for provider, user := range users {
+		wg.Add(1)
+		go func(provider, user string) {
+			defer wg.Done()
+			days, err := activityProviders[provider](r.Context(), user, now)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[provider] = apierror.FromUpstream(provider, err)
+				return
+			}
+			counts[provider] = days
+		}(provider, user)
+	}
+	wg.Wait()
+
+	if len(counts) == 0 {
+		for _, provider := range []string{"github", "leetcode", "duolingo"} {
+			if errs[provider] != nil {
+				apierror.Write(w, errs[provider])
+				return
+			}
+		}
+	}
+
+	merged := make(map[string]*ActivityDay)
+	for provider, days := range counts {
+		for date, count := range days {
+			if count <= 0 {
+				continue
+			}
+			day := merged[date]
+			if day == nil {
+				day = &ActivityDay{Date: date}
+				merged[date] = day
+			}
+			switch provider {
+			case "github":
+				day.GitHub += count
+			case "leetcode":
+				day.LeetCode += count
+			case "duolingo":
+				day.Duolingo += count
+			}
+			day.Total += count
+			day.Score++
+		}
+	}
+
+	// The streak spans the whole history; the series only the window.
+	active := make([]utils.ContributionDay, 0, len(merged))
+	for date, day := range merged {
+		active = append(active, utils.ContributionDay{Date: date, ContributionCount: day.Total})
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Date < active[j].Date
+	})
+	streak := utils.StreaksFromDays(active, loc, now)
+
+	start, _ := time.Parse(dateLayout, from)
+	end, _ := time.Parse(dateLayout, to)
+	series := []ActivityDay{}
+	activeDays := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		day := ActivityDay{Date: date}
+		if merged[date] != nil {
+			day = *merged[date]
+			activeDays++
+		}
+		series = append(series, day)
+	}
+
+	response := map[string]interface{}{
+		"users":       users,
+		"from":        from,
+		"to":          to,
+		"active_days": activeDays,
+		"streak":      streak,
+		"days":        series,
+	}
+	ttl := cache.TTL(r.Context())
+	if len(errs) > 0 {
+		response["errors"] = errs
+		ttl = 0
+	}
+	cache.WriteJSON(w, r, response, cache.Computed(ttl))
+}
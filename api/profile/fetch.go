@@ -0,0 +1,53 @@
+package profile
+
+import (
+	"context"
+
+	"api_git_leet_duo/api/cache"
+	duotools "api_git_leet_duo/api/duo/tools"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/service"
+	"api_git_leet_duo/api/git/utils"
+	leettools "api_git_leet_duo/api/leet/tools"
+)
+
+// The fetchers below share their cache keys with the single-provider
+// endpoints, so a profile warms the cache for them and the other way around.
+
+func fetchGitHubUser(ctx context.Context, login string) (service.UserInfo, error) {
+	key := cache.Key("github", "user", login, nil)
+	userInfo, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, cache.TTL(ctx), func(ctx context.Context) (service.UserInfo, error) {
+		return service.FetchUserInfo(ctx, githubclient.FromContext(ctx), login, nil)
+	})
+	return userInfo, err
+}
+
+func fetchContributions(ctx context.Context, login string) (map[int]utils.Response, error) {
+	return utils.GetContributionHistory(ctx, githubclient.FromContext(ctx), login)
+}
+
+func fetchLanguages(ctx context.Context, login string) (service.LanguageSummary, error) {
+	opts, _ := service.ParseLanguageOptions(nil)
+	key := cache.Key("github", "langs", login, opts.Params())
+	langs, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, cache.TTL(ctx), func(ctx context.Context) (service.LanguageSummary, error) {
+		langPercentage, totalBytes, err := service.CalculateLanguagePercentages(ctx, githubclient.FromContext(ctx), login, opts)
+		return service.LanguageSummary{Languages: langPercentage, TotalBytes: totalBytes}, err
+	})
+	return langs, err
+}
+
+func fetchLeetCode(ctx context.Context, username string) (*leettools.UserData, error) {
+	key := cache.Key("leetcode", "user", username, nil)
+	userData, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, cache.TTL(ctx), func(ctx context.Context) (*leettools.UserData, error) {
+		return leettools.GetUserData(ctx, username)
+	})
+	return userData, err
+}
+
+func fetchDuolingo(ctx context.Context, username string) (duotools.User, error) {
+	key := cache.Key("duolingo", "user", username, nil)
+	userData, _, err := cache.Fetch(ctx, cache.FromContext(ctx), key, cache.TTL(ctx), func(ctx context.Context) (duotools.User, error) {
+		return duotools.FetchDuolingoUser(ctx, username)
+	})
+	return userData, err
+}
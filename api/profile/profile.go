@@ -0,0 +1,217 @@
+// Package profile combines GitHub, LeetCode and Duolingo data for one person
+// in a single response.
+package profile
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"api_git_leet_duo/api/apierror"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/git/utils"
+	"api_git_leet_duo/api/validate"
+)
+
+// Section is one part of the profile: its data, or the error that prevented
+// fetching it.
+type Section struct {
+	Data  interface{}     `json:"data,omitempty"`
+	Error *apierror.Error `json:"error,omitempty"`
+}
+
+type sectionSpec struct {
+	provider string
+	fetch    func(ctx context.Context, user string, loc *time.Location) (interface{}, error)
+}
+
+// sections lists every section ?include= accepts, keyed by name.
+var sections = map[string]sectionSpec{
+	"github_user": {"github", func(ctx context.Context, user string, _ *time.Location) (interface{}, error) {
+		return fetchGitHubUser(ctx, user)
+	}},
+	"github_contributions": {"github", func(ctx context.Context, user string, loc *time.Location) (interface{}, error) {
+		graphs, err := fetchContributions(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		stats := utils.ComputeStreaks(graphs, loc, time.Now())
+		return map[string]interface{}{
+			"total":              utils.GetTotalContributions(graphs),
+			"by_year":            utils.GetContributionsByYear(graphs),
+			"first_contribution": utils.GetFirstContributionDate(graphs),
+			"streak": map[string]interface{}{
+				"current":           stats.Current,
+				"longest":           stats.Longest,
+				"contributed_today": stats.ContributedToday,
+				"today":             stats.Today,
+				"timezone":          stats.Timezone,
+			},
+		}, nil
+	}},
+	"github_languages": {"github", func(ctx context.Context, user string, _ *time.Location) (interface{}, error) {
+		langs, err := fetchLanguages(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"languages":   langs.Languages,
+			"total_bytes": langs.TotalBytes,
+		}, nil
+	}},
+	"leetcode": {"leetcode", func(ctx context.Context, user string, _ *time.Location) (interface{}, error) {
+		return fetchLeetCode(ctx, user)
+	}},
+	"duolingo": {"duolingo", func(ctx context.Context, user string, _ *time.Location) (interface{}, error) {
+		return fetchDuolingo(ctx, user)
+	}},
+}
+
+// parseInclude returns the sections selected by ?include=, defaulting to
+// every section of the providers that have a username. "github" selects
+// all GitHub sections.
+func parseInclude(include string, users map[string]string) ([]string, *apierror.Error) {
+	if include == "" {
+		var names []string
+		for name, spec := range sections {
+			if users[spec.provider] != "" {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) *apierror.Error {
+		if users[sections[name].provider] == "" {
+			return apierror.MissingParam(sections[name].provider)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		return nil
+	}
+	for _, name := range strings.Split(include, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+			continue
+		case name == "github":
+			for _, sub := range []string{"github_user", "github_contributions", "github_languages"} {
+				if err := add(sub); err != nil {
+					return nil, err
+				}
+			}
+		case sections[name].fetch != nil:
+			if err := add(name); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, apierror.InvalidParam("include", fmt.Errorf("unknown section %q", name))
+		}
+	}
+	return names, nil
+}
+
+// Profile fetches the selected sections concurrently and returns whatever
+// succeeded, with an error on each section that failed. It only answers
+// with an error status when every section failed.
+func Profile(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	users := map[string]string{
+		"github":   q.Get("github"),
+		"leetcode": q.Get("leetcode"),
+		"duolingo": q.Get("duolingo"),
+	}
+	if users["github"] == "" && users["leetcode"] == "" && users["duolingo"] == "" {
+		apierror.Write(w, apierror.New(http.StatusBadRequest, apierror.BadParam, "at least one of 'github', 'leetcode' or 'duolingo' is required"))
+		return
+	}
+	validators := map[string]func(string) error{
+		"github":   validate.GitHubLogin,
+		"leetcode": validate.LeetCodeUsername,
+		"duolingo": validate.DuolingoUsername,
+	}
+	for provider, user := range users {
+		if user == "" {
+			continue
+		}
+		if err := validators[provider](user); err != nil {
+			apierror.Write(w, apierror.BadRequest(err))
+			return
+		}
+	}
+
+	loc, err := utils.LoadTimezone(q.Get("tz"))
+	if err != nil {
+		apierror.Write(w, apierror.InvalidParam("tz", err))
+		return
+	}
+
+	names, apiErr := parseInclude(q.Get("include"), users)
+	if apiErr != nil {
+		apierror.Write(w, apiErr)
+		return
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]Section, len(names))
+	)
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			spec := sections[name]
+			data, err := spec.fetch(r.Context(), users[spec.provider], loc)
+
+			section := Section{Data: data}
+			if err != nil {
+				section = Section{Error: apierror.FromUpstream(spec.provider, err)}
+			}
+			mu.Lock()
+			results[name] = section
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+
+	var failed *apierror.Error
+	for _, name := range names {
+		if results[name].Error == nil {
+			failed = nil
+			break
+		}
+		failed = results[name].Error
+	}
+	if failed != nil {
+		apierror.Write(w, failed)
+		return
+	}
+
+	requested := make(map[string]string)
+	for provider, user := range users {
+		if user != "" {
+			requested[provider] = user
+		}
+	}
+	response := map[string]interface{}{
+		"users":    requested,
+		"sections": results,
+	}
+
+	// Partial results are not worth caching downstream.
+	ttl := cache.TTL(r.Context())
+	for _, section := range results {
+		if section.Error != nil {
+			ttl = 0
+		}
+	}
+	cache.WriteJSON(w, r, response, cache.Computed(ttl))
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+
+	"api_git_leet_duo/api/admin"
+	"api_git_leet_duo/api/cache"
+	"api_git_leet_duo/api/config"
+	"api_git_leet_duo/api/duo"
+	"api_git_leet_duo/api/git/githubclient"
+	"api_git_leet_duo/api/git/handler"
+	"api_git_leet_duo/api/leet"
+	"api_git_leet_duo/api/profile"
+	"api_git_leet_duo/api/public"
+)
+
+// Routes registers every endpoint, matching the vercel.json configuration,
+// and hands each request the configuration, GitHub client and cache built
+// from cfg.
+func Routes(cfg config.Config) http.Handler {
+	mux := http.NewServeMux()
+
+	// Serve static files from public directory
+	mux.Handle("/", http.FileServer(http.Dir("./public")))
+
+	// GitHub API
+	mux.HandleFunc("/api/git/user", handler.GitUser)
+	mux.HandleFunc("/api/git/repos", handler.GitRepos)
+	mux.HandleFunc("/api/git/repos_count", handler.GitReposCount)
+	mux.HandleFunc("/api/git/pinned", handler.GitPinned)
+	mux.HandleFunc("/api/git/top_repos", handler.GitTopRepos)
+	mux.HandleFunc("/api/git/langs", handler.GitLangs)
+	mux.HandleFunc("/api/git/langs.svg", handler.GitLangsSVG)
+	mux.HandleFunc("/api/git/streak", handler.GitStreak)
+	mux.HandleFunc("/api/git/streak.svg", handler.GitStreakSVG)
+	mux.HandleFunc("/api/git/heatmap.svg", handler.GitHeatmapSVG)
+	mux.HandleFunc("/api/git/commit", handler.GitCommit)
+	mux.HandleFunc("/api/git/stats", handler.GitStats)
+	mux.HandleFunc("/api/git/contrib_repos", handler.GitContribRepos)
+	mux.HandleFunc("/api/git/prs", handler.GitPRs)
+	mux.HandleFunc("/api/git/punchcard", handler.GitPunchcard)
+	mux.HandleFunc("/api/git/punchcard.svg", handler.GitPunchcardSVG)
+
+	// Duolingo API
+	mux.HandleFunc("/api/duo/user", duo.DuoUser)
+
+	// LeetCode API
+	mux.HandleFunc("/api/leet/user", leet.LeetUser)
+	mux.HandleFunc("/api/leet/heatmap.svg", leet.LeetHeatmapSVG)
+
+	// Combined profile
+	mux.HandleFunc("/api/profile", profile.Profile)
+	mux.HandleFunc("/api/activity", profile.Activity)
+
+	// Admin
+	mux.HandleFunc("/api/admin/tokens", admin.AdminTokens)
+
+	// Documentation route
+	mux.HandleFunc("/api/doc/", public.PublicHandle)
+
+	return withDependencies(mux, cfg, githubclient.FromConfig(cfg), cache.New(cfg))
+}
+
+// withDependencies puts cfg, client and c on every request context, where
+// handlers find them through the FromContext functions of their packages.
+func withDependencies(next http.Handler, cfg config.Config, client *githubclient.Client, c cache.Cache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := config.WithConfig(r.Context(), cfg)
+		ctx = githubclient.WithClient(ctx, client)
+		ctx = cache.WithCache(ctx, c)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,117 @@
+// Package server runs the API as a standalone HTTP server with timeouts and
+// graceful shutdown. Vercel deployments call the handlers directly.
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"api_git_leet_duo/api/config"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 10 * time.Second
+	idleTimeout       = 120 * time.Second
+	// handlerTimeout cancels the request context, and with it every
+	// outbound call, before the write deadline closes the connection.
+	handlerTimeout = 55 * time.Second
+	writeTimeout   = 60 * time.Second
+	// ShutdownTimeout is how long in-flight requests get to drain.
+	ShutdownTimeout = 30 * time.Second
+)
+
+// NewLogger returns a text logger on stderr filtered at cfg's LOG_LEVEL.
+func NewLogger(cfg config.Config) *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.Level()}))
+}
+
+// New returns an http.Server for cfg serving h with read, write and idle
+// timeouts, and a deadline on every request context.
+func New(cfg config.Config, h http.Handler) *http.Server {
+	logger := NewLogger(cfg)
+	h = withDeadline(h, handlerTimeout)
+	h = logRequests(h, logger)
+
+	return &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+}
+
+// Run serves until ctx is cancelled, then stops accepting connections and
+// waits up to ShutdownTimeout for in-flight requests to finish.
+func Run(ctx context.Context, srv *http.Server) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, draining in-flight requests")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// withDeadline cancels the request context after timeout, so upstream calls
+// fail and the handler answers with an UPSTREAM_TIMEOUT error body.
+func withDeadline(next http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests logs method, path, status and duration of every request:
+// server errors at warn, everything else at debug.
+func logRequests(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		level := slog.LevelDebug
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.RequestURI()),
+			slog.Int("status", rec.status),
+			slog.Duration("duration", time.Since(start).Round(time.Millisecond)),
+		)
+	})
+}
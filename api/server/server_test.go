@@ -0,0 +1,45 @@
+package server
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api_git_leet_duo/api/config"
+)
+
+func TestRoutesInjectsConfig(t *testing.T) {
+	h := Routes(config.Config{AdminSecret: "injected", CacheTTL: config.DefaultCacheTTL})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/admin/tokens", nil)
+	r.Header.Set("Authorization", "Bearer injected")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 with the secret of the injected config", w.Code)
+	}
+}
+
+func TestLogRequestsHonoursLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	h := logRequests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}), logger)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if buf.Len() != 0 {
+		t.Errorf("successful request logged above debug: %s", buf.String())
+	}
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	if !strings.Contains(buf.String(), "level=WARN") || !strings.Contains(buf.String(), "status=502") {
+		t.Errorf("server error not logged at warn: %s", buf.String())
+	}
+}
@@ -0,0 +1,13 @@
+// Package upstream holds the sentinel errors the LeetCode and Duolingo
+// clients wrap, so they can report failures without depending on how the
+// API renders them. Package apierror maps them to error codes.
+package upstream
+
+import "errors"
+
+var (
+	// ErrUserNotFound reports a user the provider does not know.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrRateLimited reports a request the provider refused for its rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
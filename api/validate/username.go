@@ -0,0 +1,40 @@
+// Package validate checks user supplied identifiers before they reach an
+// upstream API.
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	githubLogin      = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`)
+	leetCodeUsername = regexp.MustCompile(`^[A-Za-z0-9_-]{1,40}$`)
+	duolingoUsername = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,40}$`)
+)
+
+// GitHubLogin follows GitHub's rules: up to 39 alphanumeric characters or
+// single hyphens, not starting or ending with a hyphen.
+func GitHubLogin(login string) error {
+	if !githubLogin.MatchString(login) || strings.Contains(login, "--") {
+		return fmt.Errorf("invalid GitHub login %q: use up to 39 letters, digits or single hyphens, not starting or ending with a hyphen", login)
+	}
+	return nil
+}
+
+// LeetCodeUsername allows letters, digits, underscores and hyphens.
+func LeetCodeUsername(username string) error {
+	if !leetCodeUsername.MatchString(username) {
+		return fmt.Errorf("invalid LeetCode username %q: use up to 40 letters, digits, underscores or hyphens", username)
+	}
+	return nil
+}
+
+// DuolingoUsername allows letters, digits, underscores, dots and hyphens.
+func DuolingoUsername(username string) error {
+	if !duolingoUsername.MatchString(username) {
+		return fmt.Errorf("invalid Duolingo username %q: use up to 40 letters, digits, underscores, dots or hyphens", username)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUsernames(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		valid    []string
+		invalid  []string
+	}{
+		{
+			name:     "github",
+			validate: GitHubLogin,
+			valid:    []string{"a", "octocat", "reinan-br", "A1-b2-C3", strings.Repeat("a", 39)},
+			invalid: []string{
+				"",
+				strings.Repeat("a", 40),
+				"-octocat", "octocat-", "-",
+				"octo--cat",
+				"octo_cat", "octo.cat", "octo cat",
+				`a") { x }`, `a"){viewer{login}}#`, "a\nb",
+			},
+		},
+		{
+			name:     "leetcode",
+			validate: LeetCodeUsername,
+			valid:    []string{"a", "reinanbr", "reinan_br", "-reinan", "reinan-", strings.Repeat("a", 40)},
+			invalid: []string{
+				"",
+				strings.Repeat("a", 41),
+				"reinan.br", "reinan br", "reinan/br",
+				`a") { x }`, `a"){matchedUser}#`, "a\nb",
+			},
+		},
+		{
+			name:     "duolingo",
+			validate: DuolingoUsername,
+			valid:    []string{"a", "reinanbr", "reinan.br", "reinan_br", "-reinan", "reinan-", strings.Repeat("a", 40)},
+			invalid: []string{
+				"",
+				strings.Repeat("a", 41),
+				"reinan br", "reinan/br", "reinan?x=1", "reinan&x",
+				`a") { x }`, "a\nb",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, username := range tt.valid {
+				if err := tt.validate(username); err != nil {
+					t.Errorf("%q rejected: %v", username, err)
+				}
+			}
+			for _, username := range tt.invalid {
+				if err := tt.validate(username); err == nil {
+					t.Errorf("%q accepted", username)
+				}
+			}
+		})
+	}
+}
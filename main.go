@@ -1,38 +1,36 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
+	"log/slog"
+	"os/signal"
+	"syscall"
 
-	"api_git_leet_duo/api/duo"
-	"api_git_leet_duo/api/git/handler"
-	"api_git_leet_duo/api/leet"
-	"api_git_leet_duo/api/public"
+	"api_git_leet_duo/api/config"
+	"api_git_leet_duo/api/server"
 )
 
 func main() {
-	// Serve static files from public directory
-	http.Handle("/", http.FileServer(http.Dir("./public")))
-
-	// API routes - matching vercel.json configuration
-	http.HandleFunc("/api/git/user", handler.GitUser)
-	http.HandleFunc("/api/git/repos", handler.GitRepos)
-	http.HandleFunc("/api/git/repos_count", handler.GitReposCount)
-	http.HandleFunc("/api/git/langs", handler.GitLangs)
-	http.HandleFunc("/api/git/streak", handler.GitStreak)
-	http.HandleFunc("/api/git/commit", handler.GitCommit)
-
-	// Duolingo API
-	http.HandleFunc("/api/duo/user", duo.DuoUser)
-
-	// LeetCode API
-	http.HandleFunc("/api/leet/user", leet.LeetUser)
-
-	// Documentation route
-	http.HandleFunc("/api/doc/", public.PublicHandle)
-
-	fmt.Println("Server running on http://localhost:8080")
-	fmt.Println("API Documentation: http://localhost:8080/api/doc/")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.Set(cfg)
+	slog.SetDefault(server.NewLogger(cfg))
+	if len(cfg.GitHubTokens) == 0 {
+		slog.Warn("no GitHub token configured, GitHub endpoints will fail")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(cfg, server.Routes(cfg))
+
+	fmt.Printf("Server running on http://localhost:%s (%s)\n", cfg.Port, cfg.Environment)
+	fmt.Printf("API Documentation: http://localhost:%s/api/doc/\n", cfg.Port)
+	if err := server.Run(ctx, srv); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -81,7 +81,7 @@ func addGitInfo(response map[string]interface{}, username string) error {
 	}
 
 	// Calculate language usage percentage
-	langsPercent, totalSize := languages.CalculateLanguagePercentage(langs)
+	langsPercent, totalSize := languages.CalculateLanguagePercentage(langs, nil)
 	response["langs"] = map[string]interface{}{
 		"total_size":   totalSize,
 		"lang_percent": langsPercent,
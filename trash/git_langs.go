@@ -22,7 +22,7 @@ func GitLangs(w http.ResponseWriter, r *http.Request) {
 
 	// Monta a resposta JSON
 	response := make(map[string]interface{})
-	langs_percent,totalSize := languages.CalculateLanguagePercentage(langs)
+	langs_percent,totalSize := languages.CalculateLanguagePercentage(langs, nil)
 	response["total_size"] = totalSize
 	response["percent_lang"] = langs_percent
 